@@ -0,0 +1,138 @@
+package imagekit
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	DEFAULT_AUTH_EXPIRE_WINDOW = 30 * time.Minute
+	MAX_AUTH_EXPIRE_WINDOW     = time.Hour
+)
+
+// Represents parameters for authenticating client-side uploads.
+type AuthenticationParameters struct {
+	Token     string `json:"token" binding:"-"`
+	Expire    int64  `json:"expire" binding:"-"`
+	Signature string `json:"signature" binding:"-"`
+}
+
+// Represents an http.Handler that responds with client-side upload
+// authentication parameters.
+type AuthenticationHandler struct {
+	ImageKit       *ImageKit
+	ExpireWindow   time.Duration
+	TokenGenerator func() (token string, err error)
+}
+
+// Generates a random version 4 UUID.
+func generateToken() (token string, err error) {
+	uuid := make([]byte, 16)
+	if _, err = rand.Read(uuid); err != nil {
+		return "", err
+	}
+	uuid[6] = (uuid[6] & 0x0f) | 0x40
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+	return fmt.Sprintf(
+		"%x-%x-%x-%x-%x",
+		uuid[0:4],
+		uuid[4:6],
+		uuid[6:8],
+		uuid[8:10],
+		uuid[10:16],
+	), nil
+}
+
+// Gets the token, expire and signature for authenticating client-side
+// uploads. A random token is generated if token is empty and the expiry
+// defaults to DEFAULT_AUTH_EXPIRE_WINDOW from now if expire is 0. An
+// explicit expire must be in the future and at most MAX_AUTH_EXPIRE_WINDOW
+// from now, as the API rejects other expiry times.
+func (imgKit *ImageKit) GetAuthenticationParameters(
+	token string,
+	expire int64) (params *AuthenticationParameters, err error) {
+	if len(imgKit.PrivateKey) == 0 {
+		return nil, errors.New("privateKey must not be empty")
+	}
+	now := time.Now()
+	if expire == 0 {
+		expire = now.Add(DEFAULT_AUTH_EXPIRE_WINDOW).Unix()
+	} else if expire <= now.Unix() || expire > now.Add(MAX_AUTH_EXPIRE_WINDOW).Unix() {
+		return nil, errors.New("expire is out of bounds")
+	}
+	if len(token) == 0 {
+		token, err = generateToken()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &AuthenticationParameters{
+		Token:     token,
+		Expire:    expire,
+		Signature: getAuthenticationSignature(imgKit.PrivateKey, token, expire),
+	}, nil
+}
+
+// Computes the HMAC-SHA1 signature of a token and expiry time.
+func getAuthenticationSignature(privateKey, token string, expire int64) string {
+	mac := hmac.New(sha1.New, []byte(privateKey))
+	mac.Write([]byte(fmt.Sprintf("%s%d", token, expire)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Writes a JSON error message with the given status code.
+func writeJSONError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]string{"message": message})
+}
+
+// Responds with a fresh set of authentication parameters.
+func (handler *AuthenticationHandler) ServeHTTP(
+	w http.ResponseWriter,
+	r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		writeJSONError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if handler.ImageKit == nil {
+		writeJSONError(w, http.StatusInternalServerError, "imagekit is not configured")
+		return
+	}
+	expireWindow := handler.ExpireWindow
+	if expireWindow == 0 {
+		expireWindow = DEFAULT_AUTH_EXPIRE_WINDOW
+	}
+	if expireWindow < 0 || expireWindow > MAX_AUTH_EXPIRE_WINDOW {
+		writeJSONError(w, http.StatusInternalServerError, "expire window is out of bounds")
+		return
+	}
+	token := ""
+	if handler.TokenGenerator != nil {
+		var err error
+		token, err = handler.TokenGenerator()
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, "failed to generate token")
+			return
+		}
+	}
+	params, err := handler.ImageKit.GetAuthenticationParameters(
+		token,
+		time.Now().Add(expireWindow).Unix(),
+	)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(params)
+}
@@ -0,0 +1,67 @@
+package imagekit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAuthenticationSignature(t *testing.T) {
+	signature := getAuthenticationSignature("private_key_test", "your_token", 1582269249)
+	if signature != "e71bcd6031016b060d349d212e23e85c791decdd" {
+		t.Errorf("signature = %s", signature)
+	}
+}
+
+func TestGetAuthenticationParameters(t *testing.T) {
+	imgKit := New("public_key_test", "private_key_test", "https://ik.imagekit.io/demo")
+	expire := time.Now().Add(10 * time.Minute).Unix()
+	params, err := imgKit.GetAuthenticationParameters("your_token", expire)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params.Token != "your_token" || params.Expire != expire ||
+		params.Signature != getAuthenticationSignature("private_key_test", "your_token", expire) {
+		t.Errorf("params = %+v", params)
+	}
+	params, err = imgKit.GetAuthenticationParameters("", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(params.Token) != 36 || params.Expire <= time.Now().Unix() {
+		t.Errorf("params = %+v", params)
+	}
+	invalid := []int64{
+		time.Now().Add(-time.Minute).Unix(),
+		time.Now().Add(MAX_AUTH_EXPIRE_WINDOW + time.Minute).Unix(),
+		1582269249,
+	}
+	for _, expire := range invalid {
+		if _, err := imgKit.GetAuthenticationParameters("your_token", expire); err == nil {
+			t.Errorf("expire %d returned no error", expire)
+		}
+	}
+}
+
+func TestAuthenticationHandler(t *testing.T) {
+	handler := &AuthenticationHandler{
+		ImageKit:       New("public_key_test", "private_key_test", "https://ik.imagekit.io/demo"),
+		ExpireWindow:   MAX_AUTH_EXPIRE_WINDOW,
+		TokenGenerator: func() (string, error) { return "your_token", nil },
+	}
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/auth", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", recorder.Code, recorder.Body.String())
+	}
+	params := AuthenticationParameters{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &params); err != nil {
+		t.Fatal(err)
+	}
+	if params.Token != "your_token" ||
+		params.Signature != getAuthenticationSignature("private_key_test", "your_token", params.Expire) {
+		t.Errorf("params = %+v", params)
+	}
+}
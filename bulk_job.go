@@ -2,13 +2,47 @@ package imagekit
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 )
 
+const (
+	JOB_STATUS_PENDING   = "Pending"
+	JOB_STATUS_COMPLETED = "Completed"
+)
+
+const (
+	DEFAULT_JOB_POLL_INTERVAL     = time.Second
+	DEFAULT_JOB_MAX_POLL_INTERVAL = 30 * time.Second
+)
+
+// Represents options for waiting for a bulk job to complete.
+type BulkJobWaitOptions struct {
+	// The wait time between the first status checks, which defaults to
+	// DEFAULT_JOB_POLL_INTERVAL.
+	PollInterval time.Duration
+	// The upper bound of the wait time between status checks, which
+	// defaults to DEFAULT_JOB_MAX_POLL_INTERVAL.
+	MaxPollInterval time.Duration
+	// The factor the wait time grows by after each status check, where
+	// values below 1 give a constant interval.
+	Multiplier float64
+	// The maximum time to wait for, where 0 waits until the context is done.
+	Timeout time.Duration
+	// Called with the details of the job after every status check.
+	OnProgress func(jobDetails *JobDetails)
+}
+
 // Add tags to an array of files.
 func (imgKit *ImageKit) AddTags(fileIds, tags []string) (updatedFileIds []string, err error) {
+	return imgKit.AddTagsWithContext(context.Background(), fileIds, tags)
+}
+
+// Add tags to an array of files using the given context.
+func (imgKit *ImageKit) AddTagsWithContext(ctx context.Context, fileIds, tags []string) (updatedFileIds []string, err error) {
 	reqBody := make(map[string][]string)
 	reqBody["fileIds"] = fileIds
 	reqBody["tags"] = tags
@@ -16,9 +50,10 @@ func (imgKit *ImageKit) AddTags(fileIds, tags []string) (updatedFileIds []string
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodPost,
-		fmt.Sprintf("%s/files/addTags", BASE_URL),
+		fmt.Sprintf("%s/files/addTags", imgKit.getBaseUrl()),
 		bytes.NewBufferString(string(reqBodyBytes)),
 	)
 	if err != nil {
@@ -29,25 +64,26 @@ func (imgKit *ImageKit) AddTags(fileIds, tags []string) (updatedFileIds []string
 	if err != nil {
 		return nil, err
 	}
-	updatedFileIds = []string{}
-	err = json.Unmarshal([]byte(resBodyStr), &updatedFileIds)
-	if err != nil {
-		return nil, err
-	}
-	return updatedFileIds, nil
+	return parseUpdatedFileIds(resBodyStr)
 }
 
 // Delete an array of files.
 func (imgKit *ImageKit) DeleteFiles(fileIds []string) (deletedFileIds []string, err error) {
+	return imgKit.DeleteFilesWithContext(context.Background(), fileIds)
+}
+
+// Delete an array of files using the given context.
+func (imgKit *ImageKit) DeleteFilesWithContext(ctx context.Context, fileIds []string) (deletedFileIds []string, err error) {
 	reqBody := make(map[string][]string)
 	reqBody["fileIds"] = fileIds
 	reqBodyBytes, err := json.Marshal(reqBody)
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodPost,
-		fmt.Sprintf("%s/files/batch/deleteByFileIds", BASE_URL),
+		fmt.Sprintf("%s/files/batch/deleteByFileIds", imgKit.getBaseUrl()),
 		bytes.NewBufferString(string(reqBodyBytes)),
 	)
 	if err != nil {
@@ -58,16 +94,24 @@ func (imgKit *ImageKit) DeleteFiles(fileIds []string) (deletedFileIds []string,
 	if err != nil {
 		return nil, err
 	}
-	deletedFileIds = []string{}
-	err = json.Unmarshal([]byte(resBodyStr), &deletedFileIds)
+	response := filesDeleteResponse{}
+	err = json.Unmarshal([]byte(resBodyStr), &response)
 	if err != nil {
 		return nil, err
 	}
-	return deletedFileIds, nil
+	if response.SuccessfullyDeletedFileIds == nil {
+		return []string{}, nil
+	}
+	return response.SuccessfullyDeletedFileIds, nil
 }
 
 // Remove AI tags from an array of files.
 func (imgKit *ImageKit) RemoveAITags(fileIds, aiTags []string) (updatedFileIds []string, err error) {
+	return imgKit.RemoveAITagsWithContext(context.Background(), fileIds, aiTags)
+}
+
+// Remove AI tags from an array of files using the given context.
+func (imgKit *ImageKit) RemoveAITagsWithContext(ctx context.Context, fileIds, aiTags []string) (updatedFileIds []string, err error) {
 	reqBody := make(map[string][]string)
 	reqBody["fileIds"] = fileIds
 	reqBody["AITags"] = aiTags
@@ -75,9 +119,10 @@ func (imgKit *ImageKit) RemoveAITags(fileIds, aiTags []string) (updatedFileIds [
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodPost,
-		fmt.Sprintf("%s/files/removeAITags", BASE_URL),
+		fmt.Sprintf("%s/files/removeAITags", imgKit.getBaseUrl()),
 		bytes.NewBufferString(string(reqBodyBytes)),
 	)
 	if err != nil {
@@ -88,16 +133,16 @@ func (imgKit *ImageKit) RemoveAITags(fileIds, aiTags []string) (updatedFileIds [
 	if err != nil {
 		return nil, err
 	}
-	updatedFileIds = []string{}
-	err = json.Unmarshal([]byte(resBodyStr), &updatedFileIds)
-	if err != nil {
-		return nil, err
-	}
-	return updatedFileIds, nil
+	return parseUpdatedFileIds(resBodyStr)
 }
 
 // Remove tags from an array of files.
 func (imgKit *ImageKit) RemoveTags(fileIds, tags []string) (updatedFileIds []string, err error) {
+	return imgKit.RemoveTagsWithContext(context.Background(), fileIds, tags)
+}
+
+// Remove tags from an array of files using the given context.
+func (imgKit *ImageKit) RemoveTagsWithContext(ctx context.Context, fileIds, tags []string) (updatedFileIds []string, err error) {
 	reqBody := make(map[string][]string)
 	reqBody["fileIds"] = fileIds
 	reqBody["tags"] = tags
@@ -105,9 +150,10 @@ func (imgKit *ImageKit) RemoveTags(fileIds, tags []string) (updatedFileIds []str
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodPost,
-		fmt.Sprintf("%s/files/removeTags", BASE_URL),
+		fmt.Sprintf("%s/files/removeTags", imgKit.getBaseUrl()),
 		bytes.NewBufferString(string(reqBodyBytes)),
 	)
 	if err != nil {
@@ -118,20 +164,46 @@ func (imgKit *ImageKit) RemoveTags(fileIds, tags []string) (updatedFileIds []str
 	if err != nil {
 		return nil, err
 	}
-	updatedFileIds = []string{}
-	err = json.Unmarshal([]byte(resBodyStr), &updatedFileIds)
+	return parseUpdatedFileIds(resBodyStr)
+}
+
+// Represents the response of a request updating the tags of files.
+type tagsUpdateResponse struct {
+	SuccessfullyUpdatedFileIds []string `json:"successfullyUpdatedFileIds"`
+}
+
+// Represents the response of a request deleting files.
+type filesDeleteResponse struct {
+	SuccessfullyDeletedFileIds []string `json:"successfullyDeletedFileIds"`
+}
+
+// Parses the IDs of the files whose tags were updated from a response body.
+func parseUpdatedFileIds(resBodyStr string) (updatedFileIds []string, err error) {
+	response := tagsUpdateResponse{}
+	err = json.Unmarshal([]byte(resBodyStr), &response)
 	if err != nil {
 		return nil, err
 	}
-	return updatedFileIds, nil
+	if response.SuccessfullyUpdatedFileIds == nil {
+		return []string{}, nil
+	}
+	return response.SuccessfullyUpdatedFileIds, nil
 }
 
 // Get details of a bulk job.
 func (imgKit *ImageKit) GetBulkJobStatus(
 	jobId string) (jobDetails *JobDetails, err error) {
-	req, err := http.NewRequest(
+	return imgKit.GetBulkJobStatusWithContext(context.Background(), jobId)
+}
+
+// Get details of a bulk job using the given context.
+func (imgKit *ImageKit) GetBulkJobStatusWithContext(
+	ctx context.Context,
+	jobId string) (jobDetails *JobDetails, err error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodGet,
-		fmt.Sprintf("%s/bulkJobs/%s", BASE_URL, jobId),
+		fmt.Sprintf("%s/bulkJobs/%s", imgKit.getBaseUrl(), jobId),
 		nil,
 	)
 	if err != nil {
@@ -149,3 +221,52 @@ func (imgKit *ImageKit) GetBulkJobStatus(
 	}
 	return jobDetails, nil
 }
+
+// Wait for a bulk job to complete by polling its status, returning the
+// details of the completed job.
+func (imgKit *ImageKit) WaitForBulkJob(
+	ctx context.Context,
+	jobId string,
+	options *BulkJobWaitOptions) (jobDetails *JobDetails, err error) {
+	if options == nil {
+		options = &BulkJobWaitOptions{}
+	}
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+	interval := options.PollInterval
+	if interval <= 0 {
+		interval = DEFAULT_JOB_POLL_INTERVAL
+	}
+	maxInterval := options.MaxPollInterval
+	if maxInterval <= 0 {
+		maxInterval = DEFAULT_JOB_MAX_POLL_INTERVAL
+	}
+	for {
+		jobDetails, err = imgKit.GetBulkJobStatusWithContext(ctx, jobId)
+		if err != nil {
+			return nil, err
+		}
+		if options.OnProgress != nil {
+			options.OnProgress(jobDetails)
+		}
+		if jobDetails.Status == JOB_STATUS_COMPLETED {
+			return jobDetails, nil
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		if options.Multiplier > 1 {
+			interval = time.Duration(float64(interval) * options.Multiplier)
+		}
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
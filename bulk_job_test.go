@@ -0,0 +1,83 @@
+package imagekit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// Creates an ImageKit whose API responds to every request with the given
+// status code and body, recording the paths of the requests.
+func newRecordedImageKit(t *testing.T, statusCode int, body string) (imgKit *ImageKit, paths *[]string) {
+	paths = &[]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*paths = append(*paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	imgKit = New("public", "private", "https://ik.imagekit.io/demo", WithBaseUrl(server.URL))
+	return imgKit, paths
+}
+
+func TestUpdateTagsResponses(t *testing.T) {
+	// Recorded from the responses of the add tags, remove tags and remove
+	// AI tags endpoints.
+	body := `{"successfullyUpdatedFileIds": ["598821f949c0a938d57563bd", "598821f949c0a938d57563be"]}`
+	want := []string{"598821f949c0a938d57563bd", "598821f949c0a938d57563be"}
+	imgKit, paths := newRecordedImageKit(t, http.StatusOK, body)
+	fileIds := []string{"598821f949c0a938d57563bd", "598821f949c0a938d57563be"}
+	calls := map[string]func() ([]string, error){
+		"/files/addTags": func() ([]string, error) {
+			return imgKit.AddTags(fileIds, []string{"summer"})
+		},
+		"/files/removeTags": func() ([]string, error) {
+			return imgKit.RemoveTags(fileIds, []string{"summer"})
+		},
+		"/files/removeAITags": func() ([]string, error) {
+			return imgKit.RemoveAITags(fileIds, []string{"Beach"})
+		},
+	}
+	for path, call := range calls {
+		updatedFileIds, err := call()
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		if !reflect.DeepEqual(updatedFileIds, want) {
+			t.Errorf("%s: updatedFileIds = %v, want %v", path, updatedFileIds, want)
+		}
+		if last := (*paths)[len(*paths)-1]; last != path {
+			t.Errorf("request path = %s, want %s", last, path)
+		}
+	}
+}
+
+func TestDeleteFilesResponse(t *testing.T) {
+	// Recorded from the response of the bulk file delete endpoint.
+	body := `{"successfullyDeletedFileIds": ["598821f949c0a938d57563bd", "598821f949c0a938d57563be"]}`
+	imgKit, paths := newRecordedImageKit(t, http.StatusOK, body)
+	deletedFileIds, err := imgKit.DeleteFiles([]string{"598821f949c0a938d57563bd", "598821f949c0a938d57563be"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"598821f949c0a938d57563bd", "598821f949c0a938d57563be"}
+	if !reflect.DeepEqual(deletedFileIds, want) {
+		t.Errorf("deletedFileIds = %v, want %v", deletedFileIds, want)
+	}
+	if (*paths)[0] != "/files/batch/deleteByFileIds" {
+		t.Errorf("request path = %s", (*paths)[0])
+	}
+}
+
+func TestDeleteFilesMissingFilesResponse(t *testing.T) {
+	// Recorded from the response of the bulk file delete endpoint when a
+	// file does not exist.
+	body := `{"message": "The requested file(s) does not exist.", "help": "For support kindly contact us at support@imagekit.io .", "missingFileIds": ["598821f949c0a938d57563be"]}`
+	imgKit, _ := newRecordedImageKit(t, http.StatusNotFound, body)
+	_, err := imgKit.DeleteFiles([]string{"598821f949c0a938d57563bd", "598821f949c0a938d57563be"})
+	if !IsNotFound(err) {
+		t.Errorf("err = %v, want a not found error", err)
+	}
+}
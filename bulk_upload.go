@@ -0,0 +1,317 @@
+package imagekit
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const DEFAULT_BULK_UPLOAD_CONCURRENCY = 4
+
+// Represents a file to upload with a BulkUploader.
+type UploadJob struct {
+	// The path of a local file, which is streamed with UploadReader, or an
+	// http or https URL or a data URI with base64 encoded contents, which
+	// are passed to Upload.
+	Source   string
+	FileName string
+	Options  *FileOptions
+	// The key identifying the job in the checkpoint file, which defaults to
+	// the source, folder and file name of the job.
+	Key string
+}
+
+// Gets the key identifying the job in a checkpoint file.
+func (job *UploadJob) checkpointKey() string {
+	if len(job.Key) > 0 {
+		return job.Key
+	}
+	folder := ""
+	if job.Options != nil && job.Options.Folder != nil {
+		folder = string(*job.Options.Folder)
+	}
+	return fmt.Sprintf("%s\x00%s\x00%s", job.Source, folder, job.FileName)
+}
+
+// Represents the outcome of an upload job.
+type UploadResult struct {
+	Job UploadJob
+	// The details of the uploaded file, which is nil if the upload failed
+	// or was skipped.
+	File *FileDetails
+	Err  error
+	// Whether the job was skipped because the checkpoint file records it
+	// as uploaded.
+	Skipped bool
+}
+
+// Represents the progress of a bulk upload.
+type BulkUploadProgress struct {
+	// The number of jobs, which is 0 when they are read from a channel.
+	Total     int
+	Completed int
+	Succeeded int
+	Failed    int
+	Skipped   int
+}
+
+// Represents an uploader of many files with a pool of workers. Uploads
+// start at most RateLimit times per second, where 0 is unlimited.
+//
+// If CheckpointFile is set, the key of every successful job is appended to
+// it, and jobs it records are skipped, so an interrupted bulk upload can be
+// resumed by running it again with the same file. OnResult and OnProgress
+// are called after every job and are never called concurrently.
+type BulkUploader struct {
+	ImageKit *ImageKit
+	// The number of concurrent uploads, which defaults to
+	// DEFAULT_BULK_UPLOAD_CONCURRENCY.
+	Concurrency    int
+	RateLimit      float64
+	CheckpointFile string
+	OnResult       func(result UploadResult)
+	OnProgress     func(progress BulkUploadProgress)
+}
+
+// Represents a line of a checkpoint file.
+type checkpointEntry struct {
+	Key    string `json:"key"`
+	FileId string `json:"fileId,omitempty"`
+}
+
+// Reads the keys of the jobs recorded in a checkpoint file, ignoring lines
+// that are not complete entries.
+func readCheckpoint(checkpointFile string) (keys map[string]bool, err error) {
+	keys = make(map[string]bool)
+	file, err := os.Open(checkpointFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return keys, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		entry := checkpointEntry{}
+		if json.Unmarshal(scanner.Bytes(), &entry) == nil && len(entry.Key) > 0 {
+			keys[entry.Key] = true
+		}
+	}
+	return keys, scanner.Err()
+}
+
+// Gets the base64 encoded contents of a data URI such as
+// "data:image/png;base64,iVBORw0KGgo", without padding.
+func parseBase64DataUri(source string) (data string, ok bool) {
+	if !strings.HasPrefix(source, "data:") {
+		return "", false
+	}
+	comma := strings.Index(source, ",")
+	if comma < 0 || !strings.HasSuffix(source[:comma], ";base64") {
+		return "", false
+	}
+	data = strings.TrimRight(source[comma+1:], "=")
+	if _, err := base64.RawStdEncoding.DecodeString(data); err != nil {
+		return "", false
+	}
+	return data, true
+}
+
+// Uploads the file of a job. Sources that are not URLs or data URIs are
+// treated as local paths.
+func (uploader *BulkUploader) upload(
+	ctx context.Context,
+	job UploadJob) (result *FileDetails, err error) {
+	if sourceUrl, err := url.Parse(job.Source); err == nil &&
+		(sourceUrl.Scheme == "http" || sourceUrl.Scheme == "https") && len(sourceUrl.Host) > 0 {
+		return uploader.ImageKit.UploadWithContext(ctx, job.Source, job.FileName, job.Options)
+	}
+	if data, ok := parseBase64DataUri(job.Source); ok {
+		return uploader.ImageKit.UploadWithContext(ctx, data, job.FileName, job.Options)
+	}
+	st, err := os.Stat(job.Source)
+	if err != nil {
+		return nil, err
+	}
+	if !st.Mode().IsRegular() {
+		return nil, fmt.Errorf("%s is not a regular file", job.Source)
+	}
+	file, err := os.Open(job.Source)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return uploader.ImageKit.UploadReader(ctx, file, job.FileName, job.Options)
+}
+
+// Uploads the files of the jobs, returning the final progress. Failed jobs
+// are reported through OnResult and counted in the progress, and do not
+// stop the other jobs.
+func (uploader *BulkUploader) Upload(
+	ctx context.Context,
+	jobs []UploadJob) (progress BulkUploadProgress, err error) {
+	jobsChan := make(chan UploadJob)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		defer close(jobsChan)
+		for _, job := range jobs {
+			select {
+			case jobsChan <- job:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return uploader.run(ctx, jobsChan, len(jobs))
+}
+
+// Uploads the files of the jobs received from a channel until it is
+// closed, returning the final progress.
+func (uploader *BulkUploader) UploadChannel(
+	ctx context.Context,
+	jobs <-chan UploadJob) (progress BulkUploadProgress, err error) {
+	return uploader.run(ctx, jobs, 0)
+}
+
+// Runs the jobs received from a channel with the worker pool.
+func (uploader *BulkUploader) run(
+	ctx context.Context,
+	jobs <-chan UploadJob,
+	total int) (progress BulkUploadProgress, err error) {
+	if uploader.ImageKit == nil {
+		return progress, errors.New("ImageKit must not be nil")
+	}
+	concurrency := uploader.Concurrency
+	if concurrency == 0 {
+		concurrency = DEFAULT_BULK_UPLOAD_CONCURRENCY
+	}
+	if concurrency < 0 {
+		return progress, errors.New("concurrency is out of bounds")
+	}
+	if uploader.RateLimit < 0 {
+		return progress, errors.New("rateLimit is out of bounds")
+	}
+	uploaded := make(map[string]bool)
+	var checkpoint *os.File
+	if len(uploader.CheckpointFile) > 0 {
+		if uploaded, err = readCheckpoint(uploader.CheckpointFile); err != nil {
+			return progress, err
+		}
+		checkpoint, err = os.OpenFile(
+			uploader.CheckpointFile,
+			os.O_APPEND|os.O_CREATE|os.O_WRONLY,
+			0644,
+		)
+		if err != nil {
+			return progress, err
+		}
+		defer checkpoint.Close()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	progress.Total = total
+	var mutex sync.Mutex
+	var checkpointErr error
+	report := func(result UploadResult) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if result.Err == nil && !result.Skipped && checkpoint != nil && checkpointErr == nil {
+			entry, err := json.Marshal(checkpointEntry{
+				Key:    result.Job.checkpointKey(),
+				FileId: string(*result.File.FileId),
+			})
+			if err == nil {
+				_, err = checkpoint.Write(append(entry, '\n'))
+			}
+			if err != nil {
+				checkpointErr = err
+				cancel()
+			}
+		}
+		progress.Completed++
+		switch {
+		case result.Skipped:
+			progress.Skipped++
+		case result.Err != nil:
+			progress.Failed++
+		default:
+			progress.Succeeded++
+		}
+		if uploader.OnResult != nil {
+			uploader.OnResult(result)
+		}
+		if uploader.OnProgress != nil {
+			uploader.OnProgress(progress)
+		}
+	}
+	work := make(chan UploadJob)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range work {
+				file, err := uploader.upload(ctx, job)
+				if err == nil && (file == nil || file.FileId == nil) {
+					err = errors.New("upload response has no fileId")
+				}
+				report(UploadResult{Job: job, File: file, Err: err})
+			}
+		}()
+	}
+	var ticker *time.Ticker
+	if uploader.RateLimit > 0 {
+		ticker = time.NewTicker(time.Duration(float64(time.Second) / uploader.RateLimit))
+		defer ticker.Stop()
+	}
+	started := 0
+feed:
+	for {
+		var job UploadJob
+		var ok bool
+		select {
+		case job, ok = <-jobs:
+			if !ok {
+				break feed
+			}
+		case <-ctx.Done():
+			break feed
+		}
+		if uploaded[job.checkpointKey()] {
+			report(UploadResult{Job: job, Skipped: true})
+			continue
+		}
+		if ticker != nil && started > 0 {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				break feed
+			}
+		}
+		select {
+		case work <- job:
+			started++
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(work)
+	wg.Wait()
+	mutex.Lock()
+	defer mutex.Unlock()
+	if checkpointErr != nil {
+		return progress, checkpointErr
+	}
+	return progress, ctx.Err()
+}
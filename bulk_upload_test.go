@@ -0,0 +1,174 @@
+package imagekit_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	imagekit "github.com/B3zaleel/imagekit-go"
+	"github.com/B3zaleel/imagekit-go/imagekittest"
+)
+
+// Represents a transport that records the maximum number of concurrent
+// requests.
+type concurrencyTransport struct {
+	mutex         sync.Mutex
+	inFlight, max int
+}
+
+func (transport *concurrencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport.mutex.Lock()
+	transport.inFlight++
+	if transport.inFlight > transport.max {
+		transport.max = transport.inFlight
+	}
+	transport.mutex.Unlock()
+	defer func() {
+		transport.mutex.Lock()
+		transport.inFlight--
+		transport.mutex.Unlock()
+	}()
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// Creates upload jobs for local files with the given names.
+func newUploadJobs(t *testing.T, names ...string) []imagekit.UploadJob {
+	dir := t.TempDir()
+	jobs := []imagekit.UploadJob{}
+	for _, name := range names {
+		filePath := filepath.Join(dir, name)
+		if err := os.WriteFile(filePath, []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+		jobs = append(jobs, imagekit.UploadJob{Source: filePath, FileName: name})
+	}
+	return jobs
+}
+
+func TestBulkUploaderConcurrency(t *testing.T) {
+	server := imagekittest.NewServer()
+	defer server.Close()
+	server.SetLatency(20 * time.Millisecond)
+	transport := &concurrencyTransport{}
+	uploader := &imagekit.BulkUploader{
+		ImageKit:    server.ImageKit(imagekit.WithHttpClient(&http.Client{Transport: transport})),
+		Concurrency: 2,
+	}
+	progress, err := uploader.Upload(context.Background(), newUploadJobs(t, "a.txt", "b.txt", "c.txt", "d.txt", "e.txt", "f.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if progress.Total != 6 || progress.Completed != 6 || progress.Succeeded != 6 {
+		t.Errorf("progress = %+v", progress)
+	}
+	if transport.max != 2 {
+		t.Errorf("%d uploads ran concurrently, want 2", transport.max)
+	}
+	if len(server.Files()) != 6 {
+		t.Errorf("%d files were uploaded, want 6", len(server.Files()))
+	}
+}
+
+func TestBulkUploaderRateLimit(t *testing.T) {
+	server := imagekittest.NewServer()
+	defer server.Close()
+	uploader := &imagekit.BulkUploader{
+		ImageKit:    server.ImageKit(),
+		Concurrency: 5,
+		RateLimit:   20,
+	}
+	start := time.Now()
+	progress, err := uploader.Upload(context.Background(), newUploadJobs(t, "a.txt", "b.txt", "c.txt", "d.txt", "e.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if progress.Succeeded != 5 {
+		t.Errorf("progress = %+v", progress)
+	}
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond {
+		t.Errorf("5 uploads at 20 per second took %v, want at least 200ms", elapsed)
+	}
+}
+
+func TestBulkUploaderCheckpointResume(t *testing.T) {
+	server := imagekittest.NewServer()
+	defer server.Close()
+	server.InjectFault(imagekittest.Fault{
+		Method:     http.MethodPost,
+		PathPrefix: imagekittest.UPLOAD_PATH,
+		StatusCode: http.StatusBadRequest,
+		Times:      1,
+	})
+	checkpointFile := filepath.Join(t.TempDir(), "checkpoint.jsonl")
+	jobs := newUploadJobs(t, "a.txt", "b.txt", "c.txt")
+	results := []imagekit.UploadResult{}
+	uploader := &imagekit.BulkUploader{
+		ImageKit:       server.ImageKit(),
+		Concurrency:    1,
+		CheckpointFile: checkpointFile,
+		OnResult: func(result imagekit.UploadResult) {
+			results = append(results, result)
+		},
+	}
+	progress, err := uploader.Upload(context.Background(), jobs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := imagekit.BulkUploadProgress{Total: 3, Completed: 3, Succeeded: 2, Failed: 1}
+	if progress != want {
+		t.Errorf("progress = %+v, want %+v", progress, want)
+	}
+	if len(results) != 3 || results[0].Err == nil || !imagekit.IsBadRequest(results[0].Err) {
+		t.Fatalf("results = %+v, want the first upload to fail", results)
+	}
+	checkpoint, err := os.ReadFile(checkpointFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(checkpoint), "\n"); lines != 2 {
+		t.Errorf("checkpoint has %d entries, want 2", lines)
+	}
+	results = nil
+	progress, err = uploader.Upload(context.Background(), jobs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = imagekit.BulkUploadProgress{Total: 3, Completed: 3, Succeeded: 1, Skipped: 2}
+	if progress != want {
+		t.Errorf("resumed progress = %+v, want %+v", progress, want)
+	}
+	for _, result := range results {
+		if result.Skipped != (result.Job.FileName != "a.txt") {
+			t.Errorf("job %s skipped = %t", result.Job.FileName, result.Skipped)
+		}
+	}
+	if len(server.Files()) != 3 {
+		t.Errorf("%d files were uploaded, want each of the 3 files once", len(server.Files()))
+	}
+}
+
+func TestBulkUploaderMissingSource(t *testing.T) {
+	server := imagekittest.NewServer()
+	defer server.Close()
+	uploader := &imagekit.BulkUploader{ImageKit: server.ImageKit()}
+	results := []imagekit.UploadResult{}
+	uploader.OnResult = func(result imagekit.UploadResult) {
+		results = append(results, result)
+	}
+	jobs := []imagekit.UploadJob{{Source: filepath.Join(t.TempDir(), "missing.txt"), FileName: "missing.txt"}}
+	progress, err := uploader.Upload(context.Background(), jobs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if progress.Failed != 1 || len(results) != 1 || !os.IsNotExist(results[0].Err) {
+		t.Errorf("progress = %+v, results = %+v, want a not exist error", progress, results)
+	}
+	if len(server.Requests()) != 0 {
+		t.Errorf("requests = %+v, want none", server.Requests())
+	}
+}
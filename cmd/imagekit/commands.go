@@ -0,0 +1,470 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	imagekit "github.com/B3zaleel/imagekit-go"
+)
+
+const PURGE_POLL_INTERVAL = 2 * time.Second
+
+// Converts a value to a String, or nil if it is empty.
+func optionalString(value string) *imagekit.String {
+	if len(value) == 0 {
+		return nil
+	}
+	str := imagekit.String(value)
+	return &str
+}
+
+// Gets the value of a String, or an empty string if it is nil.
+func stringValue(str *imagekit.String) string {
+	if str == nil {
+		return ""
+	}
+	return string(*str)
+}
+
+// Splits a comma-separated list, ignoring empty items.
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Checks if a flag was set on the command line.
+func isFlagSet(flags *flag.FlagSet, name string) bool {
+	set := false
+	flags.Visit(func(f *flag.Flag) {
+		set = set || f.Name == name
+	})
+	return set
+}
+
+// Converts a list of file details to table rows.
+func fileRows(files []imagekit.FileDetails) [][]string {
+	rows := [][]string{}
+	for _, file := range files {
+		created := ""
+		if file.CreatedAt != nil {
+			created = file.CreatedAt.Format(time.RFC3339)
+		}
+		filePath := stringValue(file.FilePath)
+		if len(filePath) == 0 {
+			filePath = stringValue(file.Name) + "/"
+		}
+		rows = append(rows, []string{
+			stringValue(file.Type),
+			stringValue(file.FileId),
+			filePath,
+			strconv.Itoa(int(file.Size)),
+			created,
+		})
+	}
+	return rows
+}
+
+var fileHeaders = []string{"TYPE", "ID", "PATH", "SIZE", "CREATED"}
+
+// Writes a list of IDs under a heading.
+func (c *cli) printIds(key, heading string, ids []string) (err error) {
+	rows := [][]string{}
+	for _, id := range ids {
+		rows = append(rows, []string{id})
+	}
+	return c.print(map[string][]string{key: ids}, []string{heading}, rows)
+}
+
+// Writes the details of a bulk job.
+func (c *cli) printJob(job *imagekit.JobDetails) (err error) {
+	if c.output == OUTPUT_JSON {
+		return c.print(job, nil, nil)
+	}
+	return c.print(nil, []string{"JOB ID", "TYPE", "STATUS"}, [][]string{
+		{string(job.JobId), string(job.Type), string(job.Status)},
+	})
+}
+
+// Uploads a local file, a URL or the standard input.
+func runUpload(ctx context.Context, c *cli, args []string) (err error) {
+	flags := newFlagSet("upload", "[flags] <path|url|-> [name]")
+	folder := flags.String("folder", "", "the folder to upload to")
+	tags := flags.String("tags", "", "comma-separated tags")
+	unique := flags.Bool("unique", true, "add a suffix to the name to make it unique")
+	private := flags.Bool("private", false, "mark the file as private")
+	overwrite := flags.Bool("overwrite", true, "overwrite a file with the same name")
+	coordinates := flags.String("custom-coordinates", "", "the area of interest as x,y,width,height")
+	customMetadata := flags.String("custom-metadata", "", "custom metadata as a JSON object")
+	if err = parseArgs(flags, args, 1, 2); err != nil {
+		return err
+	}
+	source, name := flags.Arg(0), flags.Arg(1)
+	useUniqueFileName := imagekit.Bool(*unique)
+	options := &imagekit.FileOptions{
+		Folder:            optionalString(*folder),
+		UseUniqueFileName: &useUniqueFileName,
+		CustomCoordinates: optionalString(*coordinates),
+	}
+	if tagList := splitList(*tags); len(tagList) > 0 {
+		tagStrs := []imagekit.String{}
+		for _, tag := range tagList {
+			tagStrs = append(tagStrs, imagekit.String(tag))
+		}
+		options.Tags = &tagStrs
+	}
+	if isFlagSet(flags, "private") {
+		isPrivateFile := imagekit.Bool(*private)
+		options.IsPrivateFile = &isPrivateFile
+	}
+	if isFlagSet(flags, "overwrite") {
+		overwriteFile := imagekit.Bool(*overwrite)
+		options.OverwriteFile = &overwriteFile
+	}
+	if len(*customMetadata) > 0 {
+		var metadata interface{}
+		if err = json.Unmarshal([]byte(*customMetadata), &metadata); err != nil {
+			return newUsageError("custom metadata is not valid JSON: %v", err)
+		}
+		options.CustomMetadata = &metadata
+	}
+	var result *imagekit.FileDetails
+	sourceUrl, urlErr := url.Parse(source)
+	switch {
+	case source == "-":
+		if len(name) == 0 {
+			return newUsageError("a name is required to upload the standard input")
+		}
+		result, err = c.imgKit.UploadReader(ctx, c.stdin, name, options)
+	case urlErr == nil && (sourceUrl.Scheme == "http" || sourceUrl.Scheme == "https"):
+		if len(name) == 0 {
+			name = path.Base(sourceUrl.Path)
+		}
+		result, err = c.imgKit.UploadWithContext(ctx, source, name, options)
+	default:
+		var file *os.File
+		if file, err = os.Open(source); err != nil {
+			return err
+		}
+		defer file.Close()
+		if len(name) == 0 {
+			name = filepath.Base(source)
+		}
+		result, err = c.imgKit.UploadReader(ctx, file, name, options)
+	}
+	if err != nil {
+		return err
+	}
+	return c.print(result, fileHeaders, fileRows([]imagekit.FileDetails{*result}))
+}
+
+// Lists files and folders.
+func runList(ctx context.Context, c *cli, args []string) (err error) {
+	flags := newFlagSet("ls", "[flags]")
+	listType := flags.String("type", "", "the type of items to list: all, file or folder")
+	sort := flags.String("sort", "", "the sort order, such as ASC_CREATED or DESC_SIZE")
+	folderPath := flags.String("path", "", "the folder to list")
+	tags := flags.String("tags", "", "comma-separated tags of files to list")
+	fileType := flags.String("file-type", "", "the type of files to list: all, image or non-image")
+	limit := flags.Int("limit", 0, "the maximum number of items, or the page size with -all")
+	skip := flags.Int("skip", 0, "the number of items to skip")
+	query := flags.String("query", "", `a search query, such as 'name : "logo" AND size > "1mb"'`)
+	all := flags.Bool("all", false, "list every page of items")
+	if err = parseArgs(flags, args, 0, 0); err != nil {
+		return err
+	}
+	params := &imagekit.FilesFetchParams{
+		Type:        optionalString(*listType),
+		Sort:        optionalString(*sort),
+		Path:        optionalString(*folderPath),
+		SearchQuery: optionalString(*query),
+		FileType:    optionalString(*fileType),
+	}
+	if tagList := splitList(*tags); len(tagList) > 0 {
+		tagStrs := []imagekit.String{}
+		for _, tag := range tagList {
+			tagStrs = append(tagStrs, imagekit.String(tag))
+		}
+		params.Tags = &tagStrs
+	}
+	if isFlagSet(flags, "limit") {
+		limitValue := imagekit.Int32(*limit)
+		params.Limit = &limitValue
+	}
+	if isFlagSet(flags, "skip") {
+		skipValue := imagekit.Int32(*skip)
+		params.Skip = &skipValue
+	}
+	files := []imagekit.FileDetails{}
+	if *all {
+		err = c.imgKit.ListAllFiles(ctx, params, func(file imagekit.FileDetails) error {
+			files = append(files, file)
+			return nil
+		})
+	} else {
+		var page *[]imagekit.FileDetails
+		page, err = c.imgKit.GetFilesWithContext(ctx, params)
+		if page != nil {
+			files = *page
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return c.print(files, fileHeaders, fileRows(files))
+}
+
+// Shows the details of a file.
+func runInfo(ctx context.Context, c *cli, args []string) (err error) {
+	flags := newFlagSet("info", "<fileId>")
+	if err = parseArgs(flags, args, 1, 1); err != nil {
+		return err
+	}
+	file, err := c.imgKit.GetFileDetailsWithContext(ctx, flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	if c.output == OUTPUT_JSON {
+		return c.print(file, nil, nil)
+	}
+	tags := []string{}
+	if file.Tags != nil {
+		for _, tag := range *file.Tags {
+			tags = append(tags, string(tag))
+		}
+	}
+	rows := [][]string{
+		{"ID", stringValue(file.FileId)},
+		{"Name", stringValue(file.Name)},
+		{"Path", stringValue(file.FilePath)},
+		{"URL", stringValue(file.Url)},
+		{"Type", stringValue(file.FileType)},
+		{"MIME", stringValue(file.Mime)},
+		{"Size", strconv.Itoa(int(file.Size))},
+		{"Dimensions", strconv.Itoa(int(file.Width)) + "x" + strconv.Itoa(int(file.Height))},
+		{"Tags", strings.Join(tags, ",")},
+		{"Private", strconv.FormatBool(file.IsPrivateFile != nil && bool(*file.IsPrivateFile))},
+	}
+	if file.CustomMetadata != nil {
+		metadata, err := json.Marshal(*file.CustomMetadata)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, []string{"Custom metadata", string(metadata)})
+	}
+	if file.CreatedAt != nil {
+		rows = append(rows, []string{"Created", file.CreatedAt.Format(time.RFC3339)})
+	}
+	if file.UpdatedAt != nil {
+		rows = append(rows, []string{"Updated", file.UpdatedAt.Format(time.RFC3339)})
+	}
+	return c.print(nil, nil, rows)
+}
+
+// Deletes files.
+func runRemove(ctx context.Context, c *cli, args []string) (err error) {
+	flags := newFlagSet("rm", "<fileId>...")
+	if err = parseArgs(flags, args, 1, -1); err != nil {
+		return err
+	}
+	deletedFileIds, err := c.imgKit.DeleteFilesWithContext(ctx, flags.Args())
+	if err != nil {
+		return err
+	}
+	return c.printIds("deletedFileIds", "DELETED", deletedFileIds)
+}
+
+// Copies a file to a folder.
+func runCopy(ctx context.Context, c *cli, args []string) (err error) {
+	flags := newFlagSet("cp", "<filePath> <folderPath>")
+	if err = parseArgs(flags, args, 2, 2); err != nil {
+		return err
+	}
+	return c.imgKit.CopyFileWithContext(ctx, flags.Arg(0), flags.Arg(1))
+}
+
+// Moves a file to a folder.
+func runMove(ctx context.Context, c *cli, args []string) (err error) {
+	flags := newFlagSet("mv", "<filePath> <folderPath>")
+	if err = parseArgs(flags, args, 2, 2); err != nil {
+		return err
+	}
+	return c.imgKit.MoveFileWithContext(ctx, flags.Arg(0), flags.Arg(1))
+}
+
+// Renames a file.
+func runRename(ctx context.Context, c *cli, args []string) (err error) {
+	flags := newFlagSet("rename", "[-purge] <filePath> <newName>")
+	purge := flags.Bool("purge", false, "purge the CDN cache of the old URL")
+	if err = parseArgs(flags, args, 2, 2); err != nil {
+		return err
+	}
+	purgeRequestId, err := c.imgKit.RenameFileWithContext(ctx, flags.Arg(0), flags.Arg(1), *purge)
+	if err != nil || len(purgeRequestId) == 0 {
+		return err
+	}
+	return c.printFields(
+		[]string{"purgeRequestId"},
+		map[string]string{"purgeRequestId": purgeRequestId},
+	)
+}
+
+// Adds or removes the tags of files.
+func runTag(ctx context.Context, c *cli, args []string) (err error) {
+	if len(args) == 0 {
+		return newUsageError("tag requires a subcommand: add or rm")
+	}
+	var updatedFileIds []string
+	switch args[0] {
+	case "add":
+		flags := newFlagSet("tag add", "<tags> <fileId>...")
+		if err = parseArgs(flags, args[1:], 2, -1); err != nil {
+			return err
+		}
+		updatedFileIds, err = c.imgKit.AddTagsWithContext(ctx, flags.Args()[1:], splitList(flags.Arg(0)))
+	case "rm":
+		flags := newFlagSet("tag rm", "[-ai] <tags> <fileId>...")
+		ai := flags.Bool("ai", false, "remove AI tags instead of tags")
+		if err = parseArgs(flags, args[1:], 2, -1); err != nil {
+			return err
+		}
+		if *ai {
+			updatedFileIds, err = c.imgKit.RemoveAITagsWithContext(ctx, flags.Args()[1:], splitList(flags.Arg(0)))
+		} else {
+			updatedFileIds, err = c.imgKit.RemoveTagsWithContext(ctx, flags.Args()[1:], splitList(flags.Arg(0)))
+		}
+	default:
+		return newUsageError("unknown tag subcommand %q", args[0])
+	}
+	if err != nil {
+		return err
+	}
+	return c.printIds("updatedFileIds", "UPDATED", updatedFileIds)
+}
+
+// Creates, deletes, copies or moves folders.
+func runFolder(ctx context.Context, c *cli, args []string) (err error) {
+	if len(args) == 0 {
+		return newUsageError("folder requires a subcommand: create, rm, cp or mv")
+	}
+	switch args[0] {
+	case "create":
+		flags := newFlagSet("folder create", "<name> [parentPath]")
+		if err = parseArgs(flags, args[1:], 1, 2); err != nil {
+			return err
+		}
+		parentFolderPath := flags.Arg(1)
+		if len(parentFolderPath) == 0 {
+			parentFolderPath = "/"
+		}
+		return c.imgKit.CreateFolderWithContext(ctx, flags.Arg(0), parentFolderPath)
+	case "rm":
+		flags := newFlagSet("folder rm", "<folderPath>")
+		if err = parseArgs(flags, args[1:], 1, 1); err != nil {
+			return err
+		}
+		return c.imgKit.DeleteFolderWithContext(ctx, flags.Arg(0))
+	case "cp", "mv":
+		flags := newFlagSet("folder "+args[0], "[-wait] <folderPath> <destinationPath>")
+		wait := flags.Bool("wait", false, "wait for the bulk job to complete")
+		if err = parseArgs(flags, args[1:], 2, 2); err != nil {
+			return err
+		}
+		var jobId string
+		if args[0] == "cp" {
+			jobId, err = c.imgKit.CopyFolderWithContext(ctx, flags.Arg(0), flags.Arg(1))
+		} else {
+			jobId, err = c.imgKit.MoveFolderWithContext(ctx, flags.Arg(0), flags.Arg(1))
+		}
+		if err != nil {
+			return err
+		}
+		if !*wait {
+			return c.printFields([]string{"jobId"}, map[string]string{"jobId": jobId})
+		}
+		job, err := c.imgKit.WaitForBulkJob(ctx, jobId, nil)
+		if err != nil {
+			return err
+		}
+		return c.printJob(job)
+	}
+	return newUsageError("unknown folder subcommand %q", args[0])
+}
+
+// Purges the CDN cache of a URL, or shows the status of a purge.
+func runPurge(ctx context.Context, c *cli, args []string) (err error) {
+	if len(args) > 0 && args[0] == "status" {
+		flags := newFlagSet("purge status", "<requestId>")
+		if err = parseArgs(flags, args[1:], 1, 1); err != nil {
+			return err
+		}
+		status, err := c.imgKit.GetPurgeCacheStatusWithContext(ctx, flags.Arg(0))
+		if err != nil {
+			return err
+		}
+		return c.printFields([]string{"status"}, map[string]string{"status": status})
+	}
+	flags := newFlagSet("purge", "[-wait] <url>")
+	wait := flags.Bool("wait", false, "wait for the purge to complete")
+	if err = parseArgs(flags, args, 1, 1); err != nil {
+		return err
+	}
+	requestId, err := c.imgKit.PurgeCacheWithContext(ctx, flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	fields := map[string]string{"requestId": requestId}
+	if *wait {
+		for {
+			status, err := c.imgKit.GetPurgeCacheStatusWithContext(ctx, requestId)
+			if err != nil {
+				return err
+			}
+			if status == imagekit.PURGE_STATUS_COMPLETED {
+				fields["status"] = status
+				break
+			}
+			select {
+			case <-time.After(PURGE_POLL_INTERVAL):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return c.printFields([]string{"requestId", "status"}, fields)
+	}
+	return c.printFields([]string{"requestId"}, fields)
+}
+
+// Shows the status of a bulk job.
+func runJob(ctx context.Context, c *cli, args []string) (err error) {
+	if len(args) == 0 || args[0] != "status" {
+		return newUsageError("job requires the status subcommand")
+	}
+	flags := newFlagSet("job status", "[-wait] <jobId>")
+	wait := flags.Bool("wait", false, "wait for the bulk job to complete")
+	if err = parseArgs(flags, args[1:], 1, 1); err != nil {
+		return err
+	}
+	var job *imagekit.JobDetails
+	if *wait {
+		job, err = c.imgKit.WaitForBulkJob(ctx, flags.Arg(0), nil)
+	} else {
+		job, err = c.imgKit.GetBulkJobStatusWithContext(ctx, flags.Arg(0))
+	}
+	if err != nil {
+		return err
+	}
+	return c.printJob(job)
+}
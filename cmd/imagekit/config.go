@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+const (
+	ENV_PUBLIC_KEY   = "IMAGEKIT_PUBLIC_KEY"
+	ENV_PRIVATE_KEY  = "IMAGEKIT_PRIVATE_KEY"
+	ENV_URL_ENDPOINT = "IMAGEKIT_URL_ENDPOINT"
+	ENV_BASE_URL     = "IMAGEKIT_BASE_URL"
+	ENV_UPLOAD_URL   = "IMAGEKIT_UPLOAD_URL"
+	ENV_CONFIG       = "IMAGEKIT_CONFIG"
+)
+
+// Represents the credentials and endpoints of an account.
+type config struct {
+	PublicKey   string `json:"publicKey"`
+	PrivateKey  string `json:"privateKey"`
+	UrlEndpoint string `json:"urlEndpoint"`
+	BaseUrl     string `json:"baseUrl"`
+	UploadUrl   string `json:"uploadUrl"`
+}
+
+// Gets the path of the default config file, which is imagekit/config.json
+// in the user's config directory.
+func defaultConfigPath() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(configDir, "imagekit", "config.json")
+}
+
+// Loads the config from a JSON file, where values set in the environment
+// take precedence. An empty path reads the file named by IMAGEKIT_CONFIG,
+// or the default config file if it exists.
+func loadConfig(path string) (conf *config, err error) {
+	conf = &config{}
+	explicit := len(path) > 0
+	if !explicit {
+		path = os.Getenv(ENV_CONFIG)
+		explicit = len(path) > 0
+	}
+	if !explicit {
+		path = defaultConfigPath()
+	}
+	if len(path) > 0 {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			if err = json.Unmarshal(data, conf); err != nil {
+				return nil, err
+			}
+		} else if explicit || !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+	for env, value := range map[string]*string{
+		ENV_PUBLIC_KEY:   &conf.PublicKey,
+		ENV_PRIVATE_KEY:  &conf.PrivateKey,
+		ENV_URL_ENDPOINT: &conf.UrlEndpoint,
+		ENV_BASE_URL:     &conf.BaseUrl,
+		ENV_UPLOAD_URL:   &conf.UploadUrl,
+	} {
+		if envValue := os.Getenv(env); len(envValue) > 0 {
+			*value = envValue
+		}
+	}
+	if len(conf.PrivateKey) == 0 {
+		return nil, errors.New("private key is not set in " + ENV_PRIVATE_KEY + " or the config file")
+	}
+	return conf, nil
+}
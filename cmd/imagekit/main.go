@@ -0,0 +1,208 @@
+// Command imagekit manages an ImageKit.io media library.
+//
+// Credentials are read from the IMAGEKIT_PUBLIC_KEY, IMAGEKIT_PRIVATE_KEY
+// and IMAGEKIT_URL_ENDPOINT environment variables, which take precedence
+// over a JSON config file with the publicKey, privateKey and urlEndpoint
+// keys. Run "imagekit help" for the list of commands.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"text/tabwriter"
+
+	imagekit "github.com/B3zaleel/imagekit-go"
+)
+
+const (
+	OUTPUT_TABLE = "table"
+	OUTPUT_JSON  = "json"
+)
+
+const usage = `Usage: imagekit [-config file] [-output table|json] <command> [arguments]
+
+Commands:
+  upload [flags] <path|url|-> [name]      Upload a local file, a URL or stdin
+  ls [flags]                              List files and folders
+  info <fileId>                           Show the details of a file
+  rm <fileId>...                          Delete files
+  cp <filePath> <folderPath>              Copy a file to a folder
+  mv <filePath> <folderPath>              Move a file to a folder
+  rename [-purge] <filePath> <newName>    Rename a file
+  tag add <tags> <fileId>...              Add comma-separated tags to files
+  tag rm [-ai] <tags> <fileId>...         Remove tags or AI tags from files
+  folder create <name> [parentPath]       Create a folder
+  folder rm <folderPath>                  Delete a folder
+  folder cp [-wait] <folderPath> <dest>   Copy a folder as a bulk job
+  folder mv [-wait] <folderPath> <dest>   Move a folder as a bulk job
+  purge [-wait] <url>                     Purge the CDN cache of a URL
+  purge status <requestId>                Show the status of a cache purge
+  job status [-wait] <jobId>              Show the status of a bulk job
+
+Run "imagekit <command> -h" for the flags of a command.
+`
+
+// Represents an error caused by invalid command-line arguments.
+type usageError struct {
+	message string
+	// Prints the usage of the command, where nil prints the usage of the
+	// program.
+	usage func()
+}
+
+func (err *usageError) Error() string {
+	return err.message
+}
+
+// Creates an error caused by invalid command-line arguments.
+func newUsageError(format string, args ...interface{}) error {
+	return &usageError{message: fmt.Sprintf(format, args...)}
+}
+
+// Represents the state shared by commands.
+type cli struct {
+	imgKit *imagekit.ImageKit
+	output string
+	stdin  io.Reader
+	stdout io.Writer
+}
+
+// Represents a command taking the arguments following its name.
+type command func(ctx context.Context, c *cli, args []string) (err error)
+
+var commands = map[string]command{
+	"upload": runUpload,
+	"ls":     runList,
+	"info":   runInfo,
+	"rm":     runRemove,
+	"cp":     runCopy,
+	"mv":     runMove,
+	"rename": runRename,
+	"tag":    runTag,
+	"folder": runFolder,
+	"purge":  runPurge,
+	"job":    runJob,
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	err := run(ctx, os.Args[1:], os.Stdin, os.Stdout)
+	stop()
+	var usageErr *usageError
+	switch {
+	case err == nil:
+	case errors.Is(err, flag.ErrHelp):
+	case errors.As(err, &usageErr):
+		fmt.Fprintf(os.Stderr, "imagekit: %v\n\n", err)
+		if usageErr.usage != nil {
+			usageErr.usage()
+		} else {
+			fmt.Fprint(os.Stderr, usage)
+		}
+		os.Exit(2)
+	default:
+		fmt.Fprintf(os.Stderr, "imagekit: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// Runs the command named by the first non-flag argument.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) (err error) {
+	flags := flag.NewFlagSet("imagekit", flag.ContinueOnError)
+	flags.Usage = func() { fmt.Fprint(flags.Output(), usage) }
+	configPath := flags.String("config", "", "the path of the JSON config file")
+	output := flags.String("output", OUTPUT_TABLE, "the output format, table or json")
+	if err = flags.Parse(args); err != nil {
+		return err
+	}
+	if *output != OUTPUT_TABLE && *output != OUTPUT_JSON {
+		return newUsageError("unknown output format %q", *output)
+	}
+	if flags.NArg() == 0 || flags.Arg(0) == "help" {
+		fmt.Fprint(stdout, usage)
+		return nil
+	}
+	cmd, ok := commands[flags.Arg(0)]
+	if !ok {
+		return newUsageError("unknown command %q", flags.Arg(0))
+	}
+	conf, err := loadConfig(*configPath)
+	if err != nil {
+		return err
+	}
+	options := []imagekit.Option{}
+	if len(conf.BaseUrl) > 0 {
+		options = append(options, imagekit.WithBaseUrl(conf.BaseUrl))
+	}
+	if len(conf.UploadUrl) > 0 {
+		options = append(options, imagekit.WithUploadUrl(conf.UploadUrl))
+	}
+	c := &cli{
+		imgKit: imagekit.New(conf.PublicKey, conf.PrivateKey, conf.UrlEndpoint, options...),
+		output: *output,
+		stdin:  stdin,
+		stdout: stdout,
+	}
+	return cmd(ctx, c, flags.Args()[1:])
+}
+
+// Creates the flag set of a command.
+func newFlagSet(name, argsUsage string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: imagekit %s %s\n", name, argsUsage)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// Parses the flags of a command and checks its number of arguments, where
+// a negative maximum allows any number.
+func parseArgs(flags *flag.FlagSet, args []string, min, max int) (err error) {
+	if err = flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() < min || (max >= 0 && flags.NArg() > max) {
+		return &usageError{
+			message: "wrong number of arguments to " + flags.Name(),
+			usage:   flags.Usage,
+		}
+	}
+	return nil
+}
+
+// Writes a value as indented JSON, or as a table of rows under headers.
+func (c *cli) print(value interface{}, headers []string, rows [][]string) (err error) {
+	if c.output == OUTPUT_JSON {
+		encoder := json.NewEncoder(c.stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	}
+	writer := tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)
+	if len(headers) > 0 {
+		fmt.Fprintln(writer, strings.Join(headers, "\t"))
+	}
+	for _, row := range rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+	return writer.Flush()
+}
+
+// Writes key-value pairs as JSON, or as a two-column table.
+func (c *cli) printFields(keys []string, values map[string]string) (err error) {
+	if c.output == OUTPUT_JSON {
+		return c.print(values, nil, nil)
+	}
+	rows := [][]string{}
+	for _, key := range keys {
+		rows = append(rows, []string{key, values[key]})
+	}
+	return c.print(nil, nil, rows)
+}
@@ -0,0 +1,271 @@
+package imagekit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	CUSTOM_METADATA_TYPE_TEXT          = "Text"
+	CUSTOM_METADATA_TYPE_TEXTAREA      = "Textarea"
+	CUSTOM_METADATA_TYPE_NUMBER        = "Number"
+	CUSTOM_METADATA_TYPE_DATE          = "Date"
+	CUSTOM_METADATA_TYPE_BOOLEAN       = "Boolean"
+	CUSTOM_METADATA_TYPE_SINGLE_SELECT = "SingleSelect"
+	CUSTOM_METADATA_TYPE_MULTI_SELECT  = "MultiSelect"
+)
+
+var VALID_CUSTOM_METADATA_TYPES = []string{
+	CUSTOM_METADATA_TYPE_TEXT,
+	CUSTOM_METADATA_TYPE_TEXTAREA,
+	CUSTOM_METADATA_TYPE_NUMBER,
+	CUSTOM_METADATA_TYPE_DATE,
+	CUSTOM_METADATA_TYPE_BOOLEAN,
+	CUSTOM_METADATA_TYPE_SINGLE_SELECT,
+	CUSTOM_METADATA_TYPE_MULTI_SELECT,
+}
+
+// Represents the schema of a custom metadata field. MinValue and MaxValue
+// are numbers for Number fields and ISO 8601 strings for Date fields.
+type CustomMetadataFieldSchema struct {
+	Type            *String        `json:"type,omitempty" binding:"-"`
+	SelectOptions   *[]interface{} `json:"selectOptions,omitempty" binding:"-"`
+	DefaultValue    *interface{}   `json:"defaultValue,omitempty" binding:"-"`
+	IsValueRequired *Bool          `json:"isValueRequired,omitempty" binding:"-"`
+	MinValue        *interface{}   `json:"minValue,omitempty" binding:"-"`
+	MaxValue        *interface{}   `json:"maxValue,omitempty" binding:"-"`
+	MinLength       *Int32         `json:"minLength,omitempty" binding:"-"`
+	MaxLength       *Int32         `json:"maxLength,omitempty" binding:"-"`
+}
+
+// Represents details about a custom metadata field.
+type CustomMetadataFieldDetails struct {
+	Id     *String                    `json:"id" binding:"-"`
+	Name   *String                    `json:"name" binding:"-"`
+	Label  *String                    `json:"label" binding:"-"`
+	Schema *CustomMetadataFieldSchema `json:"schema" binding:"-"`
+}
+
+// Checks that the schema's options are compatible with its type.
+func (schema CustomMetadataFieldSchema) validate(fieldType String) (err error) {
+	if !fieldType.StringInArray(VALID_CUSTOM_METADATA_TYPES) {
+		return errors.New("invalid custom metadata field type")
+	}
+	isSelect := fieldType == CUSTOM_METADATA_TYPE_SINGLE_SELECT ||
+		fieldType == CUSTOM_METADATA_TYPE_MULTI_SELECT
+	isText := fieldType == CUSTOM_METADATA_TYPE_TEXT ||
+		fieldType == CUSTOM_METADATA_TYPE_TEXTAREA
+	isRange := fieldType == CUSTOM_METADATA_TYPE_NUMBER ||
+		fieldType == CUSTOM_METADATA_TYPE_DATE
+	if isSelect && (schema.SelectOptions == nil || len(*schema.SelectOptions) == 0) {
+		return errors.New("selectOptions are required for select fields")
+	}
+	if !isSelect && schema.SelectOptions != nil {
+		return errors.New("selectOptions are only supported by select fields")
+	}
+	if !isText && (schema.MinLength != nil || schema.MaxLength != nil) {
+		return errors.New("minLength and maxLength are only supported by text fields")
+	}
+	if !isRange && (schema.MinValue != nil || schema.MaxValue != nil) {
+		return errors.New("minValue and maxValue are only supported by number and date fields")
+	}
+	if schema.MinLength != nil && *schema.MinLength < 0 {
+		return errors.New("minLength is out of bounds")
+	}
+	if schema.MinLength != nil && schema.MaxLength != nil &&
+		*schema.MinLength > *schema.MaxLength {
+		return errors.New("minLength must not be greater than maxLength")
+	}
+	if schema.IsValueRequired != nil && bool(*schema.IsValueRequired) &&
+		schema.DefaultValue == nil {
+		return errors.New("defaultValue is required when isValueRequired is true")
+	}
+	return nil
+}
+
+// Create a custom metadata field.
+func (imgKit *ImageKit) CreateCustomMetadataField(
+	name,
+	label string,
+	schema CustomMetadataFieldSchema) (field *CustomMetadataFieldDetails, err error) {
+	return imgKit.CreateCustomMetadataFieldWithContext(
+		context.Background(),
+		name,
+		label,
+		schema,
+	)
+}
+
+// Create a custom metadata field using the given context.
+func (imgKit *ImageKit) CreateCustomMetadataFieldWithContext(
+	ctx context.Context,
+	name,
+	label string,
+	schema CustomMetadataFieldSchema) (field *CustomMetadataFieldDetails, err error) {
+	if len(strings.TrimSpace(name)) == 0 || len(strings.TrimSpace(label)) == 0 {
+		return nil, errors.New("name and label must not be empty")
+	}
+	if schema.Type == nil {
+		return nil, errors.New("schema type must not be empty")
+	}
+	if err = schema.validate(*schema.Type); err != nil {
+		return nil, err
+	}
+	reqBody := make(map[string]interface{})
+	reqBody["name"] = name
+	reqBody["label"] = label
+	reqBody["schema"] = schema
+	reqBodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("%s/customMetadataFields", imgKit.getBaseUrl()),
+		bytes.NewBufferString(string(reqBodyBytes)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resBodyStr, err := imgKit.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	field = &CustomMetadataFieldDetails{}
+	err = json.Unmarshal([]byte(resBodyStr), field)
+	if err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+// List custom metadata fields.
+func (imgKit *ImageKit) ListCustomMetadataFields(
+	includeDeleted bool) (fields *[]CustomMetadataFieldDetails, err error) {
+	return imgKit.ListCustomMetadataFieldsWithContext(
+		context.Background(),
+		includeDeleted,
+	)
+}
+
+// List custom metadata fields using the given context.
+func (imgKit *ImageKit) ListCustomMetadataFieldsWithContext(
+	ctx context.Context,
+	includeDeleted bool) (fields *[]CustomMetadataFieldDetails, err error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf(
+			"%s/customMetadataFields?includeDeleted=%t",
+			imgKit.getBaseUrl(),
+			includeDeleted,
+		),
+		bytes.NewBufferString(""),
+	)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resBodyStr, err := imgKit.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	fields = &[]CustomMetadataFieldDetails{}
+	err = json.Unmarshal([]byte(resBodyStr), fields)
+	if err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// Update the label or schema of a custom metadata field. The type of a
+// field cannot be changed.
+func (imgKit *ImageKit) UpdateCustomMetadataField(
+	fieldId,
+	label string,
+	schema *CustomMetadataFieldSchema) (field *CustomMetadataFieldDetails, err error) {
+	return imgKit.UpdateCustomMetadataFieldWithContext(
+		context.Background(),
+		fieldId,
+		label,
+		schema,
+	)
+}
+
+// Update the label or schema of a custom metadata field using the given
+// context.
+func (imgKit *ImageKit) UpdateCustomMetadataFieldWithContext(
+	ctx context.Context,
+	fieldId,
+	label string,
+	schema *CustomMetadataFieldSchema) (field *CustomMetadataFieldDetails, err error) {
+	reqBody := make(map[string]interface{})
+	if len(strings.TrimSpace(label)) > 0 {
+		reqBody["label"] = label
+	}
+	if schema != nil {
+		if schema.Type != nil {
+			return nil, errors.New("schema type cannot be updated")
+		}
+		reqBody["schema"] = schema
+	}
+	if len(reqBody) == 0 {
+		return nil, errors.New("either label or schema must be provided")
+	}
+	reqBodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPatch,
+		fmt.Sprintf("%s/customMetadataFields/%s", imgKit.getBaseUrl(), fieldId),
+		bytes.NewBufferString(string(reqBodyBytes)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resBodyStr, err := imgKit.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	field = &CustomMetadataFieldDetails{}
+	err = json.Unmarshal([]byte(resBodyStr), field)
+	if err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+// Delete a custom metadata field.
+func (imgKit *ImageKit) DeleteCustomMetadataField(fieldId string) (err error) {
+	return imgKit.DeleteCustomMetadataFieldWithContext(
+		context.Background(),
+		fieldId,
+	)
+}
+
+// Delete a custom metadata field using the given context.
+func (imgKit *ImageKit) DeleteCustomMetadataFieldWithContext(
+	ctx context.Context,
+	fieldId string) (err error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		fmt.Sprintf("%s/customMetadataFields/%s", imgKit.getBaseUrl(), fieldId),
+		bytes.NewBufferString(""),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = imgKit.DoRequest(req)
+	return err
+}
@@ -0,0 +1,295 @@
+package imagekit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const DEFAULT_CUSTOM_METADATA_CACHE_TTL = 5 * time.Minute
+
+// Represents a custom metadata value that does not conform to its field.
+type CustomMetadataFieldError struct {
+	Field   string
+	Message string
+}
+
+// Represents the custom metadata values that do not conform to their
+// fields.
+type CustomMetadataValidationError struct {
+	Errors []CustomMetadataFieldError
+}
+
+// Returns the messages of all field errors.
+func (validationErr *CustomMetadataValidationError) Error() string {
+	messages := make([]string, len(validationErr.Errors))
+	for i, fieldErr := range validationErr.Errors {
+		messages[i] = fmt.Sprintf("%s: %s", fieldErr.Field, fieldErr.Message)
+	}
+	return fmt.Sprintf("invalid custom metadata (%s)", strings.Join(messages, "; "))
+}
+
+// Represents a validator of custom metadata values against the custom
+// metadata fields of an account. Field definitions are cached for CacheTTL,
+// which defaults to DEFAULT_CUSTOM_METADATA_CACHE_TTL.
+type CustomMetadataValidator struct {
+	ImageKit  *ImageKit
+	CacheTTL  time.Duration
+	mutex     sync.Mutex
+	fields    map[string]CustomMetadataFieldDetails
+	fetchedAt time.Time
+}
+
+// Validates the custom metadata of uploads and file updates with a
+// validator whose field definitions are cached for cacheTTL.
+func WithCustomMetadataValidation(cacheTTL time.Duration) Option {
+	return func(imgKit *ImageKit) {
+		imgKit.CustomMetadataValidator = &CustomMetadataValidator{
+			ImageKit: imgKit,
+			CacheTTL: cacheTTL,
+		}
+	}
+}
+
+// Gets the custom metadata fields by name, fetching them if the cache is
+// empty or stale.
+func (validator *CustomMetadataValidator) Fields(
+	ctx context.Context) (fields map[string]CustomMetadataFieldDetails, err error) {
+	validator.mutex.Lock()
+	defer validator.mutex.Unlock()
+	cacheTTL := validator.CacheTTL
+	if cacheTTL == 0 {
+		cacheTTL = DEFAULT_CUSTOM_METADATA_CACHE_TTL
+	}
+	if validator.fields != nil && time.Since(validator.fetchedAt) < cacheTTL {
+		return validator.fields, nil
+	}
+	if validator.ImageKit == nil {
+		return nil, errors.New("validator has no imagekit instance")
+	}
+	fieldList, err := validator.ImageKit.ListCustomMetadataFieldsWithContext(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	fields = make(map[string]CustomMetadataFieldDetails)
+	for _, field := range *fieldList {
+		if field.Name != nil {
+			fields[string(*field.Name)] = field
+		}
+	}
+	validator.fields = fields
+	validator.fetchedAt = time.Now()
+	return fields, nil
+}
+
+// Clears the cached custom metadata fields.
+func (validator *CustomMetadataValidator) Invalidate() {
+	validator.mutex.Lock()
+	defer validator.mutex.Unlock()
+	validator.fields = nil
+}
+
+// Validates the custom metadata of file options.
+func (validator *CustomMetadataValidator) ValidateFileOptions(
+	ctx context.Context,
+	options *FileOptions) (err error) {
+	if options == nil || options.CustomMetadata == nil {
+		return nil
+	}
+	return validator.Validate(ctx, *options.CustomMetadata)
+}
+
+// Validates custom metadata, given as a map or a struct, against the
+// custom metadata fields. A *CustomMetadataValidationError lists the
+// values that do not conform to their fields and the required fields that
+// are missing.
+func (validator *CustomMetadataValidator) Validate(
+	ctx context.Context,
+	metadata interface{}) (err error) {
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	values := make(map[string]interface{})
+	if err = json.Unmarshal(metadataJSON, &values); err != nil {
+		return errors.New("custom metadata must be an object")
+	}
+	fields, err := validator.Fields(ctx)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	for name, field := range fields {
+		if _, ok := values[name]; !ok && isCustomMetadataValueRequired(field) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	validationErr := &CustomMetadataValidationError{}
+	for _, name := range names {
+		field, ok := fields[name]
+		if !ok {
+			validationErr.Errors = append(
+				validationErr.Errors,
+				CustomMetadataFieldError{Field: name, Message: "field does not exist"},
+			)
+			continue
+		}
+		if message := validateCustomMetadataValue(field, values[name]); len(message) > 0 {
+			validationErr.Errors = append(
+				validationErr.Errors,
+				CustomMetadataFieldError{Field: name, Message: message},
+			)
+		}
+	}
+	if len(validationErr.Errors) > 0 {
+		return validationErr
+	}
+	return nil
+}
+
+// Parses a date value of a custom metadata field.
+func parseCustomMetadataDate(value interface{}) (date time.Time, ok bool) {
+	str, ok := value.(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02"} {
+		if date, err := time.Parse(layout, str); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Checks if a value is one of the select options of a field.
+func isSelectOption(schema *CustomMetadataFieldSchema, value interface{}) bool {
+	if schema.SelectOptions == nil {
+		return false
+	}
+	for _, option := range *schema.SelectOptions {
+		if fmt.Sprint(option) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Checks if a custom metadata field requires a value.
+func isCustomMetadataValueRequired(field CustomMetadataFieldDetails) bool {
+	return field.Schema != nil && field.Schema.IsValueRequired != nil &&
+		bool(*field.Schema.IsValueRequired)
+}
+
+// Checks if a value is empty.
+func isEmptyCustomMetadataValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// Validates a value against its custom metadata field, returning a message
+// describing why it is invalid, if it is.
+func validateCustomMetadataValue(
+	field CustomMetadataFieldDetails,
+	value interface{}) (message string) {
+	schema := field.Schema
+	if schema == nil || schema.Type == nil {
+		return ""
+	}
+	if isEmptyCustomMetadataValue(value) {
+		if isCustomMetadataValueRequired(field) {
+			return "value is required"
+		}
+		return ""
+	}
+	switch *schema.Type {
+	case CUSTOM_METADATA_TYPE_TEXT, CUSTOM_METADATA_TYPE_TEXTAREA:
+		str, ok := value.(string)
+		if !ok {
+			return "value must be a string"
+		}
+		length := Int32(utf8.RuneCountInString(str))
+		if schema.MinLength != nil && length < *schema.MinLength {
+			return fmt.Sprintf("value must be at least %d characters long", *schema.MinLength)
+		}
+		if schema.MaxLength != nil && length > *schema.MaxLength {
+			return fmt.Sprintf("value must be at most %d characters long", *schema.MaxLength)
+		}
+	case CUSTOM_METADATA_TYPE_NUMBER:
+		number, ok := value.(float64)
+		if !ok {
+			return "value must be a number"
+		}
+		if schema.MinValue != nil {
+			if min, ok := (*schema.MinValue).(float64); ok && number < min {
+				return fmt.Sprintf("value must not be less than %v", min)
+			}
+		}
+		if schema.MaxValue != nil {
+			if max, ok := (*schema.MaxValue).(float64); ok && number > max {
+				return fmt.Sprintf("value must not be greater than %v", max)
+			}
+		}
+	case CUSTOM_METADATA_TYPE_DATE:
+		date, ok := parseCustomMetadataDate(value)
+		if !ok {
+			return "value must be an ISO 8601 date"
+		}
+		if schema.MinValue != nil {
+			if min, ok := parseCustomMetadataDate(*schema.MinValue); ok && date.Before(min) {
+				return fmt.Sprintf("value must not be before %v", *schema.MinValue)
+			}
+		}
+		if schema.MaxValue != nil {
+			if max, ok := parseCustomMetadataDate(*schema.MaxValue); ok && date.After(max) {
+				return fmt.Sprintf("value must not be after %v", *schema.MaxValue)
+			}
+		}
+	case CUSTOM_METADATA_TYPE_BOOLEAN:
+		if _, ok := value.(bool); !ok {
+			return "value must be a boolean"
+		}
+	case CUSTOM_METADATA_TYPE_SINGLE_SELECT:
+		if !isSelectOption(schema, value) {
+			return "value must be one of the select options"
+		}
+	case CUSTOM_METADATA_TYPE_MULTI_SELECT:
+		items, ok := value.([]interface{})
+		if !ok {
+			return "value must be an array"
+		}
+		for _, item := range items {
+			if !isSelectOption(schema, item) {
+				return fmt.Sprintf("%v is not one of the select options", item)
+			}
+		}
+	}
+	return ""
+}
+
+// Validates the custom metadata of file options if the ImageKit instance
+// has a custom metadata validator.
+func (imgKit *ImageKit) validateCustomMetadata(
+	ctx context.Context,
+	options *FileOptions) (err error) {
+	if imgKit.CustomMetadataValidator == nil {
+		return nil
+	}
+	return imgKit.CustomMetadataValidator.ValidateFileOptions(ctx, options)
+}
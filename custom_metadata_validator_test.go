@@ -0,0 +1,77 @@
+package imagekit
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Creates a validator with cached custom metadata fields.
+func newTestCustomMetadataValidator(fields ...CustomMetadataFieldDetails) *CustomMetadataValidator {
+	validator := &CustomMetadataValidator{
+		fields:    make(map[string]CustomMetadataFieldDetails),
+		fetchedAt: time.Now(),
+	}
+	for _, field := range fields {
+		validator.fields[string(*field.Name)] = field
+	}
+	return validator
+}
+
+// Creates a custom metadata field with the given schema.
+func newTestCustomMetadataField(name string, schema CustomMetadataFieldSchema) CustomMetadataFieldDetails {
+	fieldName := String(name)
+	return CustomMetadataFieldDetails{Name: &fieldName, Schema: &schema}
+}
+
+func TestValidateCustomMetadata(t *testing.T) {
+	textType, numberType := String(CUSTOM_METADATA_TYPE_TEXT), String(CUSTOM_METADATA_TYPE_NUMBER)
+	required, maxLength := Bool(true), Int32(5)
+	var maxValue interface{} = float64(100)
+	validator := newTestCustomMetadataValidator(
+		newTestCustomMetadataField("brand", CustomMetadataFieldSchema{Type: &textType, MaxLength: &maxLength}),
+		newTestCustomMetadataField("price", CustomMetadataFieldSchema{Type: &numberType, MaxValue: &maxValue}),
+		newTestCustomMetadataField("sku", CustomMetadataFieldSchema{Type: &textType, IsValueRequired: &required}),
+	)
+	ctx := context.Background()
+	if err := validator.Validate(ctx, map[string]interface{}{"brand": "acme", "sku": "A-1"}); err != nil {
+		t.Errorf("Validate = %v", err)
+	}
+	err := validator.Validate(ctx, map[string]interface{}{
+		"brand": "acme-corp",
+		"price": 250,
+		"color": "red",
+	})
+	validationErr := &CustomMetadataValidationError{}
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("err = %v, want a validation error", err)
+	}
+	want := []CustomMetadataFieldError{
+		{Field: "brand", Message: "value must be at most 5 characters long"},
+		{Field: "color", Message: "field does not exist"},
+		{Field: "price", Message: "value must not be greater than 100"},
+		{Field: "sku", Message: "value is required"},
+	}
+	if !reflect.DeepEqual(validationErr.Errors, want) {
+		t.Errorf("Errors = %+v, want %+v", validationErr.Errors, want)
+	}
+}
+
+func TestValidateCustomMetadataMissingRequiredField(t *testing.T) {
+	textType, required := String(CUSTOM_METADATA_TYPE_TEXT), Bool(true)
+	validator := newTestCustomMetadataValidator(
+		newTestCustomMetadataField("brand", CustomMetadataFieldSchema{Type: &textType}),
+		newTestCustomMetadataField("sku", CustomMetadataFieldSchema{Type: &textType, IsValueRequired: &required}),
+	)
+	err := validator.Validate(context.Background(), map[string]interface{}{"brand": "acme"})
+	validationErr := &CustomMetadataValidationError{}
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("err = %v, want a validation error", err)
+	}
+	want := []CustomMetadataFieldError{{Field: "sku", Message: "value is required"}}
+	if !reflect.DeepEqual(validationErr.Errors, want) {
+		t.Errorf("Errors = %+v, want %+v", validationErr.Errors, want)
+	}
+}
@@ -0,0 +1,230 @@
+package imagekit
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+)
+
+const DUPLICATE_METADATA_CONCURRENCY = 4
+
+// Represents a file and its perceptual hash.
+type DuplicateFile struct {
+	File  FileDetails
+	PHash string
+	// The Hamming distance between the file's hash and its keeper's hash.
+	Distance int
+}
+
+// Represents a file suggested to be kept and the files whose perceptual
+// hashes are within the threshold of its hash.
+type DuplicateGroup struct {
+	Keeper     DuplicateFile
+	Duplicates []DuplicateFile
+}
+
+// Represents a node of a BK-tree indexing perceptual hashes by their
+// Hamming distance.
+type bkTreeNode struct {
+	hash     string
+	index    int
+	children map[int]*bkTreeNode
+}
+
+// Adds a hash to the tree rooted at the node.
+func (node *bkTreeNode) insert(hash string, index int) (err error) {
+	for {
+		distance, err := PHashDistance(node.hash, hash)
+		if err != nil {
+			return err
+		}
+		child, ok := node.children[distance]
+		if !ok {
+			node.children[distance] = &bkTreeNode{
+				hash:     hash,
+				index:    index,
+				children: make(map[int]*bkTreeNode),
+			}
+			return nil
+		}
+		node = child
+	}
+}
+
+// Calls fn with the index of every hash within the threshold of a hash.
+func (node *bkTreeNode) search(
+	hash string,
+	threshold int,
+	fn func(index int)) (err error) {
+	distance, err := PHashDistance(node.hash, hash)
+	if err != nil {
+		return err
+	}
+	if distance <= threshold {
+		fn(node.index)
+	}
+	for childDistance, child := range node.children {
+		if childDistance >= distance-threshold && childDistance <= distance+threshold {
+			if err = child.search(hash, threshold, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Checks if a file should be kept over another, preferring higher
+// resolutions, then larger sizes, then earlier uploads.
+func isBetterKeeper(file, other FileDetails) bool {
+	resolution := int64(file.Width) * int64(file.Height)
+	otherResolution := int64(other.Width) * int64(other.Height)
+	if resolution != otherResolution {
+		return resolution > otherResolution
+	}
+	if file.Size != other.Size {
+		return file.Size > other.Size
+	}
+	if file.CreatedAt != nil && other.CreatedAt != nil {
+		return file.CreatedAt.Before(*other.CreatedAt)
+	}
+	return file.CreatedAt != nil
+}
+
+// Fetches the perceptual hashes of the images matching the parameters.
+func (imgKit *ImageKit) fetchPHashes(
+	ctx context.Context,
+	params *FilesFetchParams) (files []DuplicateFile, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	jobs := make(chan FileDetails)
+	var mutex sync.Mutex
+	var firstErr error
+	setErr := func(err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < DUPLICATE_METADATA_CONCURRENCY; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for file := range jobs {
+				metadata, err := imgKit.GetFileMetadataWithContext(ctx, string(*file.FileId))
+				if err != nil {
+					setErr(err)
+					continue
+				}
+				if metadata.PHash == nil || len(*metadata.PHash) == 0 {
+					continue
+				}
+				mutex.Lock()
+				files = append(files, DuplicateFile{File: file, PHash: string(*metadata.PHash)})
+				mutex.Unlock()
+			}
+		}()
+	}
+	err = imgKit.ListAllFiles(ctx, params, func(file FileDetails) error {
+		if file.FileId == nil || file.FileType == nil || *file.FileType != "image" {
+			return nil
+		}
+		select {
+		case jobs <- file:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	close(jobs)
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// Finds groups of images matching the parameters whose perceptual hashes
+// are within the Hamming distance threshold of the group's keeper. Keepers
+// are chosen greedily, preferring the highest resolution, then the largest
+// size, then the earliest upload, and each image belongs to at most one
+// group. Images whose hash is not hexadecimal are skipped.
+func (imgKit *ImageKit) FindDuplicates(
+	ctx context.Context,
+	params *FilesFetchParams,
+	threshold int) (groups []DuplicateGroup, err error) {
+	if threshold < 0 {
+		return nil, errors.New("threshold is out of bounds")
+	}
+	fetched, err := imgKit.fetchPHashes(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	files := []DuplicateFile{}
+	for _, file := range fetched {
+		if _, err := decodeHexHash(file.PHash); err == nil {
+			files = append(files, file)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return *files[i].File.FileId < *files[j].File.FileId
+	})
+	sort.SliceStable(files, func(i, j int) bool {
+		return isBetterKeeper(files[i].File, files[j].File)
+	})
+	// Hashes of different lengths cannot be compared, so each length gets
+	// its own tree.
+	roots := make(map[int]*bkTreeNode)
+	for i, file := range files {
+		root, ok := roots[len(file.PHash)]
+		if !ok {
+			roots[len(file.PHash)] = &bkTreeNode{hash: file.PHash, index: i, children: make(map[int]*bkTreeNode)}
+		} else if err = root.insert(file.PHash, i); err != nil {
+			return nil, err
+		}
+	}
+	grouped := make([]bool, len(files))
+	groups = []DuplicateGroup{}
+	for i, keeper := range files {
+		if grouped[i] {
+			continue
+		}
+		members := []int{}
+		err = roots[len(keeper.PHash)].search(keeper.PHash, threshold, func(j int) {
+			if j != i && !grouped[j] {
+				members = append(members, j)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(members) == 0 {
+			continue
+		}
+		grouped[i] = true
+		group := DuplicateGroup{Keeper: keeper}
+		for _, j := range members {
+			grouped[j] = true
+			duplicate := files[j]
+			duplicate.Distance, err = PHashDistance(duplicate.PHash, keeper.PHash)
+			if err != nil {
+				return nil, err
+			}
+			group.Duplicates = append(group.Duplicates, duplicate)
+		}
+		sort.Slice(group.Duplicates, func(a, b int) bool {
+			if group.Duplicates[a].Distance != group.Duplicates[b].Distance {
+				return group.Duplicates[a].Distance < group.Duplicates[b].Distance
+			}
+			return *group.Duplicates[a].File.FileId < *group.Duplicates[b].File.FileId
+		})
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
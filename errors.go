@@ -0,0 +1,93 @@
+package imagekit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Represents an error response from the ImageKit.io API.
+type APIError struct {
+	StatusCode        int
+	Message           string `json:"message" binding:"-"`
+	Help              string `json:"help" binding:"-"`
+	RequestId         string
+	RateLimitLimit    int64
+	RateLimitReset    int64
+	RateLimitInterval int64
+	Body              string
+}
+
+// Creates an APIError from a response and its body.
+func newAPIError(res *http.Response, body string) *APIError {
+	apiErr := &APIError{
+		StatusCode: res.StatusCode,
+		Body:       body,
+	}
+	json.Unmarshal([]byte(body), apiErr)
+	apiErr.RequestId = res.Header.Get("X-Ik-Requestid")
+	if len(apiErr.RequestId) == 0 {
+		apiErr.RequestId = res.Header.Get("X-Request-Id")
+	}
+	apiErr.RateLimitLimit, _ = strconv.ParseInt(
+		res.Header.Get("X-RateLimit-Limit"), 10, 64,
+	)
+	apiErr.RateLimitReset, _ = strconv.ParseInt(
+		res.Header.Get("X-RateLimit-Reset"), 10, 64,
+	)
+	apiErr.RateLimitInterval, _ = strconv.ParseInt(
+		res.Header.Get("X-RateLimit-Interval"), 10, 64,
+	)
+	return apiErr
+}
+
+// Returns the error message of the response.
+func (apiErr *APIError) Error() string {
+	message := apiErr.Message
+	if len(message) == 0 {
+		message = apiErr.Body
+	}
+	if len(message) == 0 {
+		message = http.StatusText(apiErr.StatusCode)
+	}
+	return fmt.Sprintf("%s (status %d)", message, apiErr.StatusCode)
+}
+
+// Checks if an error is an APIError with the given status code.
+func hasStatusCode(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
+// Checks if an error is caused by a malformed request.
+func IsBadRequest(err error) bool {
+	return hasStatusCode(err, http.StatusBadRequest)
+}
+
+// Checks if an error is caused by missing or invalid credentials.
+func IsUnauthorized(err error) bool {
+	return hasStatusCode(err, http.StatusUnauthorized)
+}
+
+// Checks if an error is caused by insufficient permissions.
+func IsForbidden(err error) bool {
+	return hasStatusCode(err, http.StatusForbidden)
+}
+
+// Checks if an error is caused by a missing resource.
+func IsNotFound(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+// Checks if an error is caused by exceeding the rate limit.
+func IsRateLimited(err error) bool {
+	return hasStatusCode(err, http.StatusTooManyRequests)
+}
+
+// Checks if an error is caused by a failure on the server.
+func IsServerError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= 500
+}
@@ -2,13 +2,27 @@ package imagekit
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 )
 
+// The statuses of a purge cache request.
+const (
+	PURGE_STATUS_PENDING   = "Pending"
+	PURGE_STATUS_COMPLETED = "Completed"
+)
+
 // List and search files.
 func (imgKit *ImageKit) GetFiles(
+	params *FilesFetchParams) (fileDetails *[]FileDetails, err error) {
+	return imgKit.GetFilesWithContext(context.Background(), params)
+}
+
+// List and search files using the given context.
+func (imgKit *ImageKit) GetFilesWithContext(
+	ctx context.Context,
 	params *FilesFetchParams) (fileDetails *[]FileDetails, err error) {
 	query := ""
 	if params != nil {
@@ -17,9 +31,10 @@ func (imgKit *ImageKit) GetFiles(
 			return nil, err
 		}
 	}
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodGet,
-		fmt.Sprintf("%s/files%s", BASE_URL, query),
+		fmt.Sprintf("%s/files%s", imgKit.getBaseUrl(), query),
 		bytes.NewBufferString(""),
 	)
 	if err != nil {
@@ -40,9 +55,15 @@ func (imgKit *ImageKit) GetFiles(
 
 // Get details of a file.
 func (imgKit *ImageKit) GetFileDetails(fileId string) (fileDetail *FileDetails, err error) {
-	req, err := http.NewRequest(
+	return imgKit.GetFileDetailsWithContext(context.Background(), fileId)
+}
+
+// Get details of a file using the given context.
+func (imgKit *ImageKit) GetFileDetailsWithContext(ctx context.Context, fileId string) (fileDetail *FileDetails, err error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodGet,
-		fmt.Sprintf("%s/files/%s/details", BASE_URL, fileId),
+		fmt.Sprintf("%s/files/%s/details", imgKit.getBaseUrl(), fileId),
 		bytes.NewBufferString(""),
 	)
 	if err != nil {
@@ -65,6 +86,17 @@ func (imgKit *ImageKit) GetFileDetails(fileId string) (fileDetail *FileDetails,
 func (imgKit *ImageKit) UpdateFileDetails(
 	fileId string,
 	options *FileOptions) (fileDetail *FileDetails, err error) {
+	return imgKit.UpdateFileDetailsWithContext(context.Background(), fileId, options)
+}
+
+// Update details of a file using the given context.
+func (imgKit *ImageKit) UpdateFileDetailsWithContext(
+	ctx context.Context,
+	fileId string,
+	options *FileOptions) (fileDetail *FileDetails, err error) {
+	if err = imgKit.validateCustomMetadata(ctx, options); err != nil {
+		return nil, err
+	}
 	reqBody := ""
 	if options != nil {
 		reqBody, err = options.ToJSON()
@@ -72,9 +104,10 @@ func (imgKit *ImageKit) UpdateFileDetails(
 			return nil, err
 		}
 	}
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodPatch,
-		fmt.Sprintf("%s/files/%s/details", BASE_URL, fileId),
+		fmt.Sprintf("%s/files/%s/details", imgKit.getBaseUrl(), fileId),
 		bytes.NewBufferString(reqBody),
 	)
 	if err != nil {
@@ -95,9 +128,15 @@ func (imgKit *ImageKit) UpdateFileDetails(
 
 // Delete a file.
 func (imgKit *ImageKit) DeleteFile(fileId string) (err error) {
-	req, err := http.NewRequest(
+	return imgKit.DeleteFileWithContext(context.Background(), fileId)
+}
+
+// Delete a file using the given context.
+func (imgKit *ImageKit) DeleteFileWithContext(ctx context.Context, fileId string) (err error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodDelete,
-		fmt.Sprintf("%s/files/%s", BASE_URL, fileId),
+		fmt.Sprintf("%s/files/%s", imgKit.getBaseUrl(), fileId),
 		bytes.NewBufferString(""),
 	)
 	if err != nil {
@@ -109,6 +148,11 @@ func (imgKit *ImageKit) DeleteFile(fileId string) (err error) {
 
 // Copy a file.
 func (imgKit *ImageKit) CopyFile(srcFilePath, destFolderPath string) (err error) {
+	return imgKit.CopyFileWithContext(context.Background(), srcFilePath, destFolderPath)
+}
+
+// Copy a file using the given context.
+func (imgKit *ImageKit) CopyFileWithContext(ctx context.Context, srcFilePath, destFolderPath string) (err error) {
 	reqBody := make(map[string]string)
 	reqBody["sourceFilePath"] = srcFilePath
 	reqBody["destinationPath"] = destFolderPath
@@ -116,11 +160,15 @@ func (imgKit *ImageKit) CopyFile(srcFilePath, destFolderPath string) (err error)
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodPost,
-		fmt.Sprintf("%s/files/copy", BASE_URL),
+		fmt.Sprintf("%s/files/copy", imgKit.getBaseUrl()),
 		bytes.NewBufferString(string(reqBodyBytes)),
 	)
+	if err != nil {
+		return err
+	}
 	req.Header.Set("Content-Type", "application/json")
 	_, err = imgKit.DoRequest(req)
 	return err
@@ -128,6 +176,11 @@ func (imgKit *ImageKit) CopyFile(srcFilePath, destFolderPath string) (err error)
 
 // Move a file.
 func (imgKit *ImageKit) MoveFile(srcFilePath, destFolderPath string) (err error) {
+	return imgKit.MoveFileWithContext(context.Background(), srcFilePath, destFolderPath)
+}
+
+// Move a file using the given context.
+func (imgKit *ImageKit) MoveFileWithContext(ctx context.Context, srcFilePath, destFolderPath string) (err error) {
 	reqBody := make(map[string]string)
 	reqBody["sourceFilePath"] = srcFilePath
 	reqBody["destinationPath"] = destFolderPath
@@ -135,11 +188,15 @@ func (imgKit *ImageKit) MoveFile(srcFilePath, destFolderPath string) (err error)
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodPost,
-		fmt.Sprintf("%s/files/move", BASE_URL),
+		fmt.Sprintf("%s/files/move", imgKit.getBaseUrl()),
 		bytes.NewBufferString(string(reqBodyBytes)),
 	)
+	if err != nil {
+		return err
+	}
 	req.Header.Set("Content-Type", "application/json")
 	_, err = imgKit.DoRequest(req)
 	return err
@@ -147,6 +204,11 @@ func (imgKit *ImageKit) MoveFile(srcFilePath, destFolderPath string) (err error)
 
 // Rename a file.
 func (imgKit *ImageKit) RenameFile(srcFilePath, newFileName string, purgeCache ...bool) (purgeRequestId string, err error) {
+	return imgKit.RenameFileWithContext(context.Background(), srcFilePath, newFileName, purgeCache...)
+}
+
+// Rename a file using the given context.
+func (imgKit *ImageKit) RenameFileWithContext(ctx context.Context, srcFilePath, newFileName string, purgeCache ...bool) (purgeRequestId string, err error) {
 	reqBody := make(map[string]interface{})
 	reqBody["filePath"] = srcFilePath
 	reqBody["newFileName"] = newFileName
@@ -158,11 +220,15 @@ func (imgKit *ImageKit) RenameFile(srcFilePath, newFileName string, purgeCache .
 	if err != nil {
 		return "", err
 	}
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodPut,
-		fmt.Sprintf("%s/files/rename", BASE_URL),
+		fmt.Sprintf("%s/files/rename", imgKit.getBaseUrl()),
 		bytes.NewBufferString(string(reqBodyBytes)),
 	)
+	if err != nil {
+		return "", err
+	}
 	req.Header.Set("Content-Type", "application/json")
 	resBodyStr, err := imgKit.DoRequest(req)
 	if err != nil {
@@ -178,17 +244,26 @@ func (imgKit *ImageKit) RenameFile(srcFilePath, newFileName string, purgeCache .
 
 // Purge a file's cache.
 func (imgKit *ImageKit) PurgeCache(fileUrl string) (requestId string, err error) {
+	return imgKit.PurgeCacheWithContext(context.Background(), fileUrl)
+}
+
+// Purge a file's cache using the given context.
+func (imgKit *ImageKit) PurgeCacheWithContext(ctx context.Context, fileUrl string) (requestId string, err error) {
 	reqBody := make(map[string]interface{})
 	reqBody["fileUrl"] = fileUrl
 	reqBodyBytes, err := json.Marshal(reqBody)
 	if err != nil {
 		return "", err
 	}
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodPost,
-		fmt.Sprintf("%s/files/purge", BASE_URL),
+		fmt.Sprintf("%s/files/purge", imgKit.getBaseUrl()),
 		bytes.NewBufferString(string(reqBodyBytes)),
 	)
+	if err != nil {
+		return "", err
+	}
 	req.Header.Set("Content-Type", "application/json")
 	resBodyStr, err := imgKit.DoRequest(req)
 	if err != nil {
@@ -199,16 +274,26 @@ func (imgKit *ImageKit) PurgeCache(fileUrl string) (requestId string, err error)
 	if err != nil {
 		return "", err
 	}
-	return (*responseFields)["purgeRequestId"], nil
+	return (*responseFields)["requestId"], nil
 }
 
-// Get the status of a purge cache.
+// Get the status of a purge cache, which is one of the PURGE_STATUS_*
+// constants.
 func (imgKit *ImageKit) GetPurgeCacheStatus(requestId string) (status string, err error) {
-	req, err := http.NewRequest(
+	return imgKit.GetPurgeCacheStatusWithContext(context.Background(), requestId)
+}
+
+// Get the status of a purge cache using the given context.
+func (imgKit *ImageKit) GetPurgeCacheStatusWithContext(ctx context.Context, requestId string) (status string, err error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodGet,
-		fmt.Sprintf("%s/files/purge/%s", BASE_URL, requestId),
+		fmt.Sprintf("%s/files/purge/%s", imgKit.getBaseUrl(), requestId),
 		nil,
 	)
+	if err != nil {
+		return "", err
+	}
 	req.Header.Set("Content-Type", "application/json")
 	resBodyStr, err := imgKit.DoRequest(req)
 	if err != nil {
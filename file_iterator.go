@@ -0,0 +1,153 @@
+package imagekit
+
+import (
+	"context"
+	"errors"
+)
+
+// Represents a page of files fetched by a FileIterator.
+type filePage struct {
+	files []FileDetails
+	err   error
+}
+
+// Represents an iterator over all files matching a set of fetch parameters.
+// The Limit of the parameters sets the page size and the Skip sets the
+// offset of the first page.
+type FileIterator struct {
+	imgKit   *ImageKit
+	ctx      context.Context
+	cancel   context.CancelFunc
+	params   FilesFetchParams
+	limit    Int32
+	skip     Int32
+	prefetch bool
+	pending  chan filePage
+	page     []FileDetails
+	index    int
+	current  FileDetails
+	done     bool
+	err      error
+}
+
+// Creates an iterator over the files matching the given parameters. The
+// next page is fetched in the background while the current one is consumed
+// if prefetch is true. The iterator is released once Next returns false, or
+// by Close if iteration stops early.
+func (imgKit *ImageKit) NewFileIterator(
+	ctx context.Context,
+	params *FilesFetchParams,
+	prefetch bool) *FileIterator {
+	ctx, cancel := context.WithCancel(ctx)
+	iter := &FileIterator{
+		imgKit:   imgKit,
+		ctx:      ctx,
+		cancel:   cancel,
+		limit:    MAX_LIMIT_VALUE,
+		skip:     MIN_SKIP_VALUE,
+		prefetch: prefetch,
+	}
+	if params != nil {
+		iter.params = *params
+		if params.Limit != nil {
+			iter.limit = *params.Limit
+		}
+		if params.Skip != nil {
+			iter.skip = *params.Skip
+		}
+	}
+	if iter.limit < MIN_LIMIT_VALUE || iter.limit > MAX_LIMIT_VALUE {
+		iter.err = errors.New("limit is out of bounds")
+		cancel()
+	}
+	return iter
+}
+
+// Fetches the page of files starting at the given offset.
+func (iter *FileIterator) fetchPage(skip Int32) filePage {
+	params := iter.params
+	limit := iter.limit
+	params.Limit = &limit
+	params.Skip = &skip
+	files, err := iter.imgKit.GetFilesWithContext(iter.ctx, &params)
+	if err != nil {
+		return filePage{err: err}
+	}
+	return filePage{files: *files}
+}
+
+// Gets the next page of files, waiting for a prefetched page if any.
+func (iter *FileIterator) nextPage() filePage {
+	if iter.pending != nil {
+		page := <-iter.pending
+		iter.pending = nil
+		return page
+	}
+	return iter.fetchPage(iter.skip)
+}
+
+// Advances the iterator to the next file, returning false and releasing
+// the iterator when there are no more files or an error occurred.
+func (iter *FileIterator) Next() bool {
+	for {
+		if iter.index < len(iter.page) {
+			iter.current = iter.page[iter.index]
+			iter.index++
+			return true
+		}
+		if iter.done || iter.err != nil {
+			iter.cancel()
+			return false
+		}
+		page := iter.nextPage()
+		if page.err != nil {
+			iter.err = page.err
+			iter.cancel()
+			return false
+		}
+		iter.page, iter.index = page.files, 0
+		iter.skip += Int32(len(page.files))
+		if Int32(len(page.files)) < iter.limit {
+			iter.done = true
+		} else if iter.prefetch {
+			iter.pending = make(chan filePage, 1)
+			go func(pending chan filePage, skip Int32) {
+				pending <- iter.fetchPage(skip)
+			}(iter.pending, iter.skip)
+		}
+	}
+}
+
+// Gets the file the iterator is at.
+func (iter *FileIterator) Value() FileDetails {
+	return iter.current
+}
+
+// Gets the error that stopped the iterator, if any.
+func (iter *FileIterator) Err() error {
+	return iter.err
+}
+
+// Stops the iterator and cancels any page being prefetched, so that Next
+// returns false even if files of the current page remain.
+func (iter *FileIterator) Close() {
+	iter.done = true
+	iter.page, iter.index = nil, 0
+	iter.cancel()
+}
+
+// Calls fn for every file matching the given parameters, fetching pages
+// as needed. Iteration stops at the first error returned by fn.
+func (imgKit *ImageKit) ListAllFiles(
+	ctx context.Context,
+	params *FilesFetchParams,
+	fn func(file FileDetails) error) (err error) {
+	iter := imgKit.NewFileIterator(ctx, params, true)
+	defer iter.Close()
+	for iter.Next() {
+		if err = fn(iter.Value()); err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
@@ -0,0 +1,75 @@
+package imagekit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Creates an ImageKit whose API lists the given number of files.
+func newFileListImageKit(t *testing.T, fileCount int) *ImageKit {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		files := []string{}
+		for i := skip; i < fileCount && i < skip+limit; i++ {
+			files = append(files, fmt.Sprintf(`{"fileId": "file%d"}`, i))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("[" + strings.Join(files, ",") + "]"))
+	}))
+	t.Cleanup(server.Close)
+	return New("public", "private", "https://ik.imagekit.io/demo", WithBaseUrl(server.URL))
+}
+
+func TestFileIteratorReleasedWhenExhausted(t *testing.T) {
+	for _, prefetch := range []bool{false, true} {
+		imgKit := newFileListImageKit(t, 5)
+		limit := Int32(2)
+		iter := imgKit.NewFileIterator(context.Background(), &FilesFetchParams{Limit: &limit}, prefetch)
+		count := 0
+		for iter.Next() {
+			if fileId := string(*iter.Value().FileId); fileId != fmt.Sprintf("file%d", count) {
+				t.Errorf("file %d has ID %s", count, fileId)
+			}
+			count++
+		}
+		if iter.Err() != nil || count != 5 {
+			t.Errorf("prefetch %t: iterated over %d files with error %v, want 5", prefetch, count, iter.Err())
+		}
+		if iter.ctx.Err() == nil {
+			t.Errorf("prefetch %t: the context of an exhausted iterator was not canceled", prefetch)
+		}
+	}
+}
+
+func TestFileIteratorReleasedOnError(t *testing.T) {
+	imgKit, _ := newRecordedImageKit(t, http.StatusBadRequest, `{"message": "Invalid search query."}`)
+	iter := imgKit.NewFileIterator(context.Background(), nil, true)
+	if iter.Next() {
+		t.Fatal("Next returned true")
+	}
+	if !IsBadRequest(iter.Err()) {
+		t.Errorf("Err = %v, want a bad request error", iter.Err())
+	}
+	if iter.ctx.Err() == nil {
+		t.Error("the context of a failed iterator was not canceled")
+	}
+}
+
+func TestFileIteratorClose(t *testing.T) {
+	imgKit := newFileListImageKit(t, 5)
+	limit := Int32(2)
+	iter := imgKit.NewFileIterator(context.Background(), &FilesFetchParams{Limit: &limit}, true)
+	if !iter.Next() {
+		t.Fatal("Next returned false")
+	}
+	iter.Close()
+	if iter.Next() {
+		t.Error("Next returned true after Close")
+	}
+}
@@ -0,0 +1,36 @@
+package imagekit
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestPurgeCacheResponse(t *testing.T) {
+	// Recorded from the response of the purge cache endpoint.
+	imgKit, paths := newRecordedImageKit(t, http.StatusCreated, `{"requestId": "598821f949c0a938d57563bd"}`)
+	requestId, err := imgKit.PurgeCache("https://ik.imagekit.io/demo/default-image.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requestId != "598821f949c0a938d57563bd" {
+		t.Errorf("requestId = %q", requestId)
+	}
+	if (*paths)[0] != "/files/purge" {
+		t.Errorf("request path = %s", (*paths)[0])
+	}
+}
+
+func TestGetPurgeCacheStatusResponse(t *testing.T) {
+	// Recorded from the response of the purge cache status endpoint.
+	imgKit, paths := newRecordedImageKit(t, http.StatusOK, `{"status": "Pending"}`)
+	status, err := imgKit.GetPurgeCacheStatus("598821f949c0a938d57563bd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != PURGE_STATUS_PENDING {
+		t.Errorf("status = %q, want %q", status, PURGE_STATUS_PENDING)
+	}
+	if (*paths)[0] != "/files/purge/598821f949c0a938d57563bd" {
+		t.Errorf("request path = %s", (*paths)[0])
+	}
+}
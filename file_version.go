@@ -0,0 +1,160 @@
+package imagekit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// List the versions of a file.
+func (imgKit *ImageKit) GetFileVersions(
+	fileId string) (fileVersions *[]FileDetails, err error) {
+	return imgKit.GetFileVersionsWithContext(context.Background(), fileId)
+}
+
+// List the versions of a file using the given context.
+func (imgKit *ImageKit) GetFileVersionsWithContext(
+	ctx context.Context,
+	fileId string) (fileVersions *[]FileDetails, err error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s/files/%s/versions", imgKit.getBaseUrl(), fileId),
+		bytes.NewBufferString(""),
+	)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resBodyStr, err := imgKit.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	fileVersions = &[]FileDetails{}
+	err = json.Unmarshal([]byte(resBodyStr), fileVersions)
+	if err != nil {
+		return nil, err
+	}
+	return fileVersions, nil
+}
+
+// Get details of a version of a file.
+func (imgKit *ImageKit) GetFileVersionDetails(
+	fileId,
+	versionId string) (fileVersion *FileDetails, err error) {
+	return imgKit.GetFileVersionDetailsWithContext(
+		context.Background(),
+		fileId,
+		versionId,
+	)
+}
+
+// Get details of a version of a file using the given context.
+func (imgKit *ImageKit) GetFileVersionDetailsWithContext(
+	ctx context.Context,
+	fileId,
+	versionId string) (fileVersion *FileDetails, err error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf(
+			"%s/files/%s/versions/%s",
+			imgKit.getBaseUrl(),
+			fileId,
+			versionId,
+		),
+		bytes.NewBufferString(""),
+	)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resBodyStr, err := imgKit.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	fileVersion = &FileDetails{}
+	err = json.Unmarshal([]byte(resBodyStr), fileVersion)
+	if err != nil {
+		return nil, err
+	}
+	return fileVersion, nil
+}
+
+// Restore a version of a file as its current version.
+func (imgKit *ImageKit) RestoreFileVersion(
+	fileId,
+	versionId string) (fileDetail *FileDetails, err error) {
+	return imgKit.RestoreFileVersionWithContext(
+		context.Background(),
+		fileId,
+		versionId,
+	)
+}
+
+// Restore a version of a file as its current version using the given
+// context.
+func (imgKit *ImageKit) RestoreFileVersionWithContext(
+	ctx context.Context,
+	fileId,
+	versionId string) (fileDetail *FileDetails, err error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		fmt.Sprintf(
+			"%s/files/%s/versions/%s/restore",
+			imgKit.getBaseUrl(),
+			fileId,
+			versionId,
+		),
+		bytes.NewBufferString(""),
+	)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resBodyStr, err := imgKit.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	fileDetail = &FileDetails{}
+	err = json.Unmarshal([]byte(resBodyStr), fileDetail)
+	if err != nil {
+		return nil, err
+	}
+	return fileDetail, nil
+}
+
+// Delete a non-current version of a file.
+func (imgKit *ImageKit) DeleteFileVersion(fileId, versionId string) (err error) {
+	return imgKit.DeleteFileVersionWithContext(
+		context.Background(),
+		fileId,
+		versionId,
+	)
+}
+
+// Delete a non-current version of a file using the given context.
+func (imgKit *ImageKit) DeleteFileVersionWithContext(
+	ctx context.Context,
+	fileId,
+	versionId string) (err error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		fmt.Sprintf(
+			"%s/files/%s/versions/%s",
+			imgKit.getBaseUrl(),
+			fileId,
+			versionId,
+		),
+		bytes.NewBufferString(""),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = imgKit.DoRequest(req)
+	return err
+}
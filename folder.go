@@ -2,6 +2,7 @@ package imagekit
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -9,6 +10,11 @@ import (
 
 // Create a folder.
 func (imgKit *ImageKit) CreateFolder(folderName, parentFolderPath string) (err error) {
+	return imgKit.CreateFolderWithContext(context.Background(), folderName, parentFolderPath)
+}
+
+// Create a folder using the given context.
+func (imgKit *ImageKit) CreateFolderWithContext(ctx context.Context, folderName, parentFolderPath string) (err error) {
 	reqBody := make(map[string]string)
 	reqBody["folderName"] = folderName
 	reqBody["parentFolderPath"] = parentFolderPath
@@ -16,9 +22,10 @@ func (imgKit *ImageKit) CreateFolder(folderName, parentFolderPath string) (err e
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodPost,
-		fmt.Sprintf("%s/folder", BASE_URL),
+		fmt.Sprintf("%s/folder", imgKit.getBaseUrl()),
 		bytes.NewBufferString(string(reqBodyBytes)),
 	)
 	if err != nil {
@@ -31,15 +38,21 @@ func (imgKit *ImageKit) CreateFolder(folderName, parentFolderPath string) (err e
 
 // Delete a folder.
 func (imgKit *ImageKit) DeleteFolder(folderPath string) (err error) {
+	return imgKit.DeleteFolderWithContext(context.Background(), folderPath)
+}
+
+// Delete a folder using the given context.
+func (imgKit *ImageKit) DeleteFolderWithContext(ctx context.Context, folderPath string) (err error) {
 	reqBody := make(map[string]string)
 	reqBody["folderPath"] = folderPath
 	reqBodyBytes, err := json.Marshal(reqBody)
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodDelete,
-		fmt.Sprintf("%s/folder", BASE_URL),
+		fmt.Sprintf("%s/folder", imgKit.getBaseUrl()),
 		bytes.NewBufferString(string(reqBodyBytes)),
 	)
 	if err != nil {
@@ -52,6 +65,11 @@ func (imgKit *ImageKit) DeleteFolder(folderPath string) (err error) {
 
 // Copy a folder.
 func (imgKit *ImageKit) CopyFolder(sourceFolderPath, destinationPath string) (jobId string, err error) {
+	return imgKit.CopyFolderWithContext(context.Background(), sourceFolderPath, destinationPath)
+}
+
+// Copy a folder using the given context.
+func (imgKit *ImageKit) CopyFolderWithContext(ctx context.Context, sourceFolderPath, destinationPath string) (jobId string, err error) {
 	reqBody := make(map[string]string)
 	reqBody["sourceFolderPath"] = sourceFolderPath
 	reqBody["destinationPath"] = destinationPath
@@ -59,9 +77,10 @@ func (imgKit *ImageKit) CopyFolder(sourceFolderPath, destinationPath string) (jo
 	if err != nil {
 		return "", err
 	}
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodPost,
-		fmt.Sprintf("%s/bulkJobs/copyFolder", BASE_URL),
+		fmt.Sprintf("%s/bulkJobs/copyFolder", imgKit.getBaseUrl()),
 		bytes.NewBufferString(string(reqBodyBytes)),
 	)
 	if err != nil {
@@ -82,6 +101,11 @@ func (imgKit *ImageKit) CopyFolder(sourceFolderPath, destinationPath string) (jo
 
 // Move a folder.
 func (imgKit *ImageKit) MoveFolder(sourceFolderPath, destinationPath string) (jobId string, err error) {
+	return imgKit.MoveFolderWithContext(context.Background(), sourceFolderPath, destinationPath)
+}
+
+// Move a folder using the given context.
+func (imgKit *ImageKit) MoveFolderWithContext(ctx context.Context, sourceFolderPath, destinationPath string) (jobId string, err error) {
 	reqBody := make(map[string]string)
 	reqBody["sourceFolderPath"] = sourceFolderPath
 	reqBody["destinationPath"] = destinationPath
@@ -89,9 +113,10 @@ func (imgKit *ImageKit) MoveFolder(sourceFolderPath, destinationPath string) (jo
 	if err != nil {
 		return "", err
 	}
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodPost,
-		fmt.Sprintf("%s/bulkJobs/moveFolder", BASE_URL),
+		fmt.Sprintf("%s/bulkJobs/moveFolder", imgKit.getBaseUrl()),
 		bytes.NewBufferString(string(reqBodyBytes)),
 	)
 	if err != nil {
@@ -20,6 +20,11 @@ const (
 	MAX_LIMIT_VALUE = 1000
 )
 
+const (
+	DEFAULT_TIMEOUT    = 360 * time.Second
+	DEFAULT_USER_AGENT = "Mozilla/5.0 (Windows NT 10.0; Win64; x64)"
+)
+
 var VALID_TYPES = []string{"all", "file", "folder"}
 var VALID_FILE_TYPES = []string{"all", "image", "non-image"}
 var VALID_SORT_FIELDS = []string{
@@ -37,14 +42,124 @@ var VALID_SORT_FIELDS = []string{
 	"DESC_SIZE",
 }
 
+// The http client shared by ImageKit instances without a client of their own.
+var defaultHttpClient = &http.Client{
+	Timeout: DEFAULT_TIMEOUT,
+}
+
 // Represents a struct with routines for managing assets on imagekit.io.
 type ImageKit struct {
 	PublicKey, PrivateKey, UrlEndpoint string
+	HttpClient                         *http.Client
+	BaseUrl, UploadUrl, UserAgent      string
+	RetryPolicy                        *RetryPolicy
+	CustomMetadataValidator            *CustomMetadataValidator
+}
+
+// Represents a function that configures an ImageKit instance.
+type Option func(imgKit *ImageKit)
+
+// Creates an ImageKit instance with the given credentials and options.
+func New(
+	publicKey,
+	privateKey,
+	urlEndpoint string,
+	options ...Option) *ImageKit {
+	imgKit := &ImageKit{
+		PublicKey:   publicKey,
+		PrivateKey:  privateKey,
+		UrlEndpoint: urlEndpoint,
+	}
+	for _, option := range options {
+		option(imgKit)
+	}
+	return imgKit
+}
+
+// Sets the http client used for requests.
+func WithHttpClient(client *http.Client) Option {
+	return func(imgKit *ImageKit) {
+		imgKit.HttpClient = client
+	}
+}
+
+// Sets the transport of the http client used for requests.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(imgKit *ImageKit) {
+		client := *imgKit.getHttpClient()
+		client.Transport = transport
+		imgKit.HttpClient = &client
+	}
+}
+
+// Sets the timeout of the http client used for requests.
+func WithTimeout(timeout time.Duration) Option {
+	return func(imgKit *ImageKit) {
+		client := *imgKit.getHttpClient()
+		client.Timeout = timeout
+		imgKit.HttpClient = &client
+	}
+}
+
+// Sets the base URL of the API.
+func WithBaseUrl(baseUrl string) Option {
+	return func(imgKit *ImageKit) {
+		imgKit.BaseUrl = baseUrl
+	}
+}
+
+// Sets the URL of the upload API.
+func WithUploadUrl(uploadUrl string) Option {
+	return func(imgKit *ImageKit) {
+		imgKit.UploadUrl = uploadUrl
+	}
+}
+
+// Sets the user agent sent with requests.
+func WithUserAgent(userAgent string) Option {
+	return func(imgKit *ImageKit) {
+		imgKit.UserAgent = userAgent
+	}
+}
+
+// Gets the http client used for requests.
+func (imgKit *ImageKit) getHttpClient() *http.Client {
+	if imgKit.HttpClient != nil {
+		return imgKit.HttpClient
+	}
+	return defaultHttpClient
+}
+
+// Gets the base URL of the API.
+func (imgKit *ImageKit) getBaseUrl() string {
+	if len(imgKit.BaseUrl) > 0 {
+		return strings.TrimRight(imgKit.BaseUrl, "/")
+	}
+	return BASE_URL
+}
+
+// Gets the URL of the upload API.
+func (imgKit *ImageKit) getUploadUrl() string {
+	if len(imgKit.UploadUrl) > 0 {
+		return imgKit.UploadUrl
+	}
+	return UPLOAD_URL
+}
+
+// Gets the user agent sent with requests.
+func (imgKit *ImageKit) getUserAgent() string {
+	if len(imgKit.UserAgent) > 0 {
+		return imgKit.UserAgent
+	}
+	return DEFAULT_USER_AGENT
 }
 
 // Represents a typed 32-bit integer value.
 type Int32 int32
 
+// Represents a typed 64-bit floating point value.
+type Float64 float64
+
 // Represents a typed boolean value.
 type Bool bool
 
@@ -74,6 +189,7 @@ type FileDetails struct {
 	Type              *String           `json:"type" binding:"-"`
 	Name              *String           `json:"name" binding:"-"`
 	FilePath          *String           `json:"filePath" binding:"-"`
+	FolderPath        *String           `json:"folderPath" binding:"-"`
 	Tags              *[]String         `json:"tags" binding:"-"`
 	AITags            *[]interface{}    `json:"AITags" binding:"-"`
 	IsPrivateFile     *Bool             `json:"isPrivateFile" binding:"-"`
@@ -91,6 +207,13 @@ type FileDetails struct {
 	CreatedAt         *time.Time        `json:"createdAt" binding:"-" time_format:"YYYY-MM-DDTHH:mm:ss.sssZ"`
 	UpdatedAt         *time.Time        `json:"updatedAt" binding:"-" time_format:"YYYY-MM-DDTHH:mm:ss.sssZ"`
 	ExtensionStatus   map[string]string `json:"extensionStatus" binding:"-"`
+	VersionInfo       *VersionInfo      `json:"versionInfo" binding:"-"`
+}
+
+// Represents details about a version of a file.
+type VersionInfo struct {
+	Id   *String `json:"id" binding:"-"`
+	Name *String `json:"name" binding:"-"`
 }
 
 // Represents query parameters for fetching files from imagekit.io.
@@ -108,44 +231,68 @@ type JobDetails struct {
 	Status String `json:"status" binding:"-"`
 }
 
-// Runs an http request.
+// Runs an http request, retrying failures according to the retry policy
+// until the request's context is done. Requests with a body are only
+// retried if the body can be replayed through GetBody.
 func (imgKit *ImageKit) DoRequest(req *http.Request) (body string, err error) {
-	client := &http.Client{
-		Timeout: time.Second * 360,
-	}
-	fetchRequest := true
-	res := new(http.Response)
+	client := imgKit.getHttpClient()
+	policy := imgKit.getRetryPolicy()
+	ctx := req.Context()
 	req.Header.Set("Connection", "Keep-Alive")
 	req.Header.Set("Accept-Language", "en-US")
 	req.Header.Set("Accept", "text/html,application/xml,application/json;*/*;")
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64)")
+	req.Header.Set("User-Agent", imgKit.getUserAgent())
 	req.SetBasicAuth(imgKit.PrivateKey, "")
-	for fetchRequest {
-		res, err = client.Do(req)
-		if err != nil {
-			return "", err
-		}
-		if res.StatusCode != 429 {
-			fetchRequest = false
+	canReplay := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			req.Body, err = req.GetBody()
+			if err != nil {
+				return "", err
+			}
 		}
-		if res.StatusCode == 429 {
-			waitTime, err := strconv.Atoi(res.Header.Get("X-RateLimit-Reset"))
+		canRetry := canReplay && attempt < policy.MaxAttempts
+		var waitTime time.Duration
+		res, err := client.Do(req)
+		if err != nil {
+			if !canRetry || !policy.isRetryableNetworkError(req) || ctx.Err() != nil {
+				return "", err
+			}
+			waitTime = policy.backoff(attempt)
+		} else {
+			body, err = readBody(res)
 			if err != nil {
 				return "", err
 			}
-			time.Sleep(time.Millisecond * (time.Duration(waitTime) / time.Millisecond))
+			if res.StatusCode >= 200 && res.StatusCode <= 299 {
+				return body, nil
+			}
+			if !canRetry || !policy.isRetryableStatus(req, res.StatusCode) {
+				return "", newAPIError(res, body)
+			}
+			waitTime = policy.responseBackoff(attempt, res)
+		}
+		timer := time.NewTimer(waitTime)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return "", ctx.Err()
+		case <-timer.C:
 		}
 	}
-	buf := new(bytes.Buffer)
-	if buf == nil || res.Body == nil {
-		return "", errors.New("failed to create bytes buffer")
+}
+
+// Reads and closes the body of a response.
+func readBody(res *http.Response) (body string, err error) {
+	if res.Body == nil {
+		return "", errors.New("response has no body")
 	}
-	buf.ReadFrom(res.Body)
-	body = buf.String()
-	if res.StatusCode < 200 || res.StatusCode > 299 {
-		return "", errors.New(body)
+	defer res.Body.Close()
+	buf := new(bytes.Buffer)
+	if _, err = buf.ReadFrom(res.Body); err != nil {
+		return "", err
 	}
-	return body, nil
+	return buf.String(), nil
 }
 
 // Joins a typed string array to a string.
@@ -341,11 +488,13 @@ func (options FileOptions) ToJSON() (jsonStr string, err error) {
 func (params FilesFetchParams) BuildURLQuery() (query string, err error) {
 	var queryBuilder strings.Builder
 	delim := '0'
-	queryPut := func(str string) {
+	queryPut := func(key, value string) {
 		if delim != '0' {
 			queryBuilder.WriteRune(delim)
 		}
-		queryBuilder.WriteString(url.QueryEscape(str))
+		queryBuilder.WriteString(key)
+		queryBuilder.WriteRune('=')
+		queryBuilder.WriteString(url.QueryEscape(value))
 		delim = '&'
 	}
 	queryBuilder.WriteRune('?')
@@ -353,62 +502,56 @@ func (params FilesFetchParams) BuildURLQuery() (query string, err error) {
 		if !(*params.Type).StringInArray(VALID_TYPES) {
 			return "", errors.New("invalid type value")
 		}
-		queryPut(fmt.Sprintf("type=%s", *params.Type))
+		queryPut("type", string(*params.Type))
 	}
 	if params.Sort != nil {
 		if !(*params.Sort).StringInArray(VALID_SORT_FIELDS) {
 			return "", errors.New("invalid sort value")
 		}
-		queryPut(fmt.Sprintf("sort=%s", *params.Sort))
+		queryPut("sort", string(*params.Sort))
 	}
 	if params.Path != nil {
-		queryPut(fmt.Sprintf("path=%s", *params.Path))
+		queryPut("path", string(*params.Path))
 	}
 	if params.SearchQuery != nil {
-		queryPut(fmt.Sprintf("searchQuery=%s", *params.SearchQuery))
+		queryPut("searchQuery", string(*params.SearchQuery))
 	}
 	if params.Tags != nil {
-		queryPut(fmt.Sprintf("tags=%s", joinStringArray(*params.Tags, ",", false)))
+		queryPut("tags", joinStringArray(*params.Tags, ",", false))
 	}
 	if params.FileType != nil {
 		if !(*params.FileType).StringInArray(VALID_FILE_TYPES) {
 			return "", nil
 		}
-		queryPut(fmt.Sprintf("fileType=%s", *params.FileType))
+		queryPut("fileType", string(*params.FileType))
 	}
 	if params.Limit != nil {
 		if *params.Limit < MIN_LIMIT_VALUE || *params.Limit > MAX_LIMIT_VALUE {
 			return "", errors.New("limit is out of bounds")
 		}
-		queryPut(fmt.Sprintf("limit=%d", *params.Limit))
+		queryPut("limit", fmt.Sprintf("%d", *params.Limit))
 	}
 	if params.Skip != nil {
 		if *params.Skip < MIN_SKIP_VALUE {
 			return "", errors.New("skip is out of bounds")
 		}
-		queryPut(fmt.Sprintf("skip=%d", *params.Skip))
+		queryPut("skip", fmt.Sprintf("%d", *params.Skip))
 	}
 	return queryBuilder.String(), nil
 }
 
-// Computes the hamming distance between two hexadecimal strings.
-func (imgKit *ImageKit) PHashDistance(hash1, hash2 string) (distance int8) {
-	distance = 0
-	if len(hash1) != len(hash2) {
-		panic("the hashes must be of equal length")
-	}
-	dig1, err := strconv.ParseUint(hash1, 16, 64)
+// Computes the hamming distance between two hexadecimal strings of at
+// most 64 bits.
+//
+// Deprecated: Use the package-level PHashDistance, which supports longer
+// hashes.
+func (imgKit *ImageKit) PHashDistance(hash1, hash2 string) (distance int8, err error) {
+	if len(hash1) > 16 || len(hash2) > 16 {
+		return 0, errors.New("the hashes must not be longer than 64 bits")
+	}
+	hammingDistance, err := PHashDistance(hash1, hash2)
 	if err != nil {
-		panic(err)
-	}
-	dig2, err := strconv.ParseUint(hash2, 16, 64)
-	if err != nil {
-		panic(err)
-	}
-	for i := 0; i < 64; i++ {
-		if (dig1>>i)&1 != (dig2>>i)&1 {
-			distance++
-		}
+		return 0, err
 	}
-	return distance
+	return int8(hammingDistance), nil
 }
@@ -0,0 +1,43 @@
+package imagekitmock
+
+import (
+	"context"
+
+	imagekit "github.com/B3zaleel/imagekit-go"
+)
+
+var _ imagekit.BulkJobService = (*BulkJobService)(nil)
+
+// Represents a mock of the bulk job operations that records calls.
+type BulkJobService struct {
+	Recorder
+	GetBulkJobStatusFunc func(ctx context.Context, jobId string) (*imagekit.JobDetails, error)
+	WaitForBulkJobFunc   func(ctx context.Context, jobId string, options *imagekit.BulkJobWaitOptions) (*imagekit.JobDetails, error)
+}
+
+// Records a call to GetBulkJobStatus.
+func (mock *BulkJobService) GetBulkJobStatus(jobId string) (jobDetails *imagekit.JobDetails, err error) {
+	mock.record("GetBulkJobStatus", jobId)
+	if mock.GetBulkJobStatusFunc == nil {
+		return
+	}
+	return mock.GetBulkJobStatusFunc(context.Background(), jobId)
+}
+
+// Records a call to GetBulkJobStatusWithContext.
+func (mock *BulkJobService) GetBulkJobStatusWithContext(ctx context.Context, jobId string) (jobDetails *imagekit.JobDetails, err error) {
+	mock.record("GetBulkJobStatusWithContext", jobId)
+	if mock.GetBulkJobStatusFunc == nil {
+		return
+	}
+	return mock.GetBulkJobStatusFunc(ctx, jobId)
+}
+
+// Records a call to WaitForBulkJob.
+func (mock *BulkJobService) WaitForBulkJob(ctx context.Context, jobId string, options *imagekit.BulkJobWaitOptions) (jobDetails *imagekit.JobDetails, err error) {
+	mock.record("WaitForBulkJob", jobId, options)
+	if mock.WaitForBulkJobFunc == nil {
+		return
+	}
+	return mock.WaitForBulkJobFunc(ctx, jobId, options)
+}
@@ -0,0 +1,52 @@
+package imagekitmock
+
+import (
+	"context"
+
+	imagekit "github.com/B3zaleel/imagekit-go"
+)
+
+var _ imagekit.CacheService = (*CacheService)(nil)
+
+// Represents a mock of the cache operations that records calls.
+type CacheService struct {
+	Recorder
+	PurgeCacheFunc          func(ctx context.Context, fileUrl string) (string, error)
+	GetPurgeCacheStatusFunc func(ctx context.Context, requestId string) (string, error)
+}
+
+// Records a call to PurgeCache.
+func (mock *CacheService) PurgeCache(fileUrl string) (requestId string, err error) {
+	mock.record("PurgeCache", fileUrl)
+	if mock.PurgeCacheFunc == nil {
+		return
+	}
+	return mock.PurgeCacheFunc(context.Background(), fileUrl)
+}
+
+// Records a call to PurgeCacheWithContext.
+func (mock *CacheService) PurgeCacheWithContext(ctx context.Context, fileUrl string) (requestId string, err error) {
+	mock.record("PurgeCacheWithContext", fileUrl)
+	if mock.PurgeCacheFunc == nil {
+		return
+	}
+	return mock.PurgeCacheFunc(ctx, fileUrl)
+}
+
+// Records a call to GetPurgeCacheStatus.
+func (mock *CacheService) GetPurgeCacheStatus(requestId string) (status string, err error) {
+	mock.record("GetPurgeCacheStatus", requestId)
+	if mock.GetPurgeCacheStatusFunc == nil {
+		return
+	}
+	return mock.GetPurgeCacheStatusFunc(context.Background(), requestId)
+}
+
+// Records a call to GetPurgeCacheStatusWithContext.
+func (mock *CacheService) GetPurgeCacheStatusWithContext(ctx context.Context, requestId string) (status string, err error) {
+	mock.record("GetPurgeCacheStatusWithContext", requestId)
+	if mock.GetPurgeCacheStatusFunc == nil {
+		return
+	}
+	return mock.GetPurgeCacheStatusFunc(ctx, requestId)
+}
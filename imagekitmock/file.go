@@ -0,0 +1,195 @@
+package imagekitmock
+
+import (
+	"context"
+
+	imagekit "github.com/B3zaleel/imagekit-go"
+)
+
+var _ imagekit.FileService = (*FileService)(nil)
+
+// Represents a mock of the file operations that records calls.
+type FileService struct {
+	Recorder
+	GetFilesFunc          func(ctx context.Context, params *imagekit.FilesFetchParams) (*[]imagekit.FileDetails, error)
+	ListAllFilesFunc      func(ctx context.Context, params *imagekit.FilesFetchParams, fn func(file imagekit.FileDetails) error) error
+	GetFileDetailsFunc    func(ctx context.Context, fileId string) (*imagekit.FileDetails, error)
+	UpdateFileDetailsFunc func(ctx context.Context, fileId string, options *imagekit.FileOptions) (*imagekit.FileDetails, error)
+	GetFileMetadataFunc   func(ctx context.Context, fileId string) (*imagekit.Metadata, error)
+	DeleteFileFunc        func(ctx context.Context, fileId string) error
+	DeleteFilesFunc       func(ctx context.Context, fileIds []string) ([]string, error)
+	CopyFileFunc          func(ctx context.Context, srcFilePath string, destFolderPath string) error
+	MoveFileFunc          func(ctx context.Context, srcFilePath string, destFolderPath string) error
+	RenameFileFunc        func(ctx context.Context, srcFilePath string, newFileName string, purgeCache ...bool) (string, error)
+}
+
+// Records a call to GetFiles.
+func (mock *FileService) GetFiles(params *imagekit.FilesFetchParams) (fileDetails *[]imagekit.FileDetails, err error) {
+	mock.record("GetFiles", params)
+	if mock.GetFilesFunc == nil {
+		return
+	}
+	return mock.GetFilesFunc(context.Background(), params)
+}
+
+// Records a call to GetFilesWithContext.
+func (mock *FileService) GetFilesWithContext(ctx context.Context, params *imagekit.FilesFetchParams) (fileDetails *[]imagekit.FileDetails, err error) {
+	mock.record("GetFilesWithContext", params)
+	if mock.GetFilesFunc == nil {
+		return
+	}
+	return mock.GetFilesFunc(ctx, params)
+}
+
+// Records a call to ListAllFiles.
+func (mock *FileService) ListAllFiles(ctx context.Context, params *imagekit.FilesFetchParams, fn func(file imagekit.FileDetails) error) (err error) {
+	mock.record("ListAllFiles", params, fn)
+	if mock.ListAllFilesFunc == nil {
+		return
+	}
+	return mock.ListAllFilesFunc(ctx, params, fn)
+}
+
+// Records a call to GetFileDetails.
+func (mock *FileService) GetFileDetails(fileId string) (fileDetail *imagekit.FileDetails, err error) {
+	mock.record("GetFileDetails", fileId)
+	if mock.GetFileDetailsFunc == nil {
+		return
+	}
+	return mock.GetFileDetailsFunc(context.Background(), fileId)
+}
+
+// Records a call to GetFileDetailsWithContext.
+func (mock *FileService) GetFileDetailsWithContext(ctx context.Context, fileId string) (fileDetail *imagekit.FileDetails, err error) {
+	mock.record("GetFileDetailsWithContext", fileId)
+	if mock.GetFileDetailsFunc == nil {
+		return
+	}
+	return mock.GetFileDetailsFunc(ctx, fileId)
+}
+
+// Records a call to UpdateFileDetails.
+func (mock *FileService) UpdateFileDetails(fileId string, options *imagekit.FileOptions) (fileDetail *imagekit.FileDetails, err error) {
+	mock.record("UpdateFileDetails", fileId, options)
+	if mock.UpdateFileDetailsFunc == nil {
+		return
+	}
+	return mock.UpdateFileDetailsFunc(context.Background(), fileId, options)
+}
+
+// Records a call to UpdateFileDetailsWithContext.
+func (mock *FileService) UpdateFileDetailsWithContext(ctx context.Context, fileId string, options *imagekit.FileOptions) (fileDetail *imagekit.FileDetails, err error) {
+	mock.record("UpdateFileDetailsWithContext", fileId, options)
+	if mock.UpdateFileDetailsFunc == nil {
+		return
+	}
+	return mock.UpdateFileDetailsFunc(ctx, fileId, options)
+}
+
+// Records a call to GetFileMetadata.
+func (mock *FileService) GetFileMetadata(fileId string) (metadata *imagekit.Metadata, err error) {
+	mock.record("GetFileMetadata", fileId)
+	if mock.GetFileMetadataFunc == nil {
+		return
+	}
+	return mock.GetFileMetadataFunc(context.Background(), fileId)
+}
+
+// Records a call to GetFileMetadataWithContext.
+func (mock *FileService) GetFileMetadataWithContext(ctx context.Context, fileId string) (metadata *imagekit.Metadata, err error) {
+	mock.record("GetFileMetadataWithContext", fileId)
+	if mock.GetFileMetadataFunc == nil {
+		return
+	}
+	return mock.GetFileMetadataFunc(ctx, fileId)
+}
+
+// Records a call to DeleteFile.
+func (mock *FileService) DeleteFile(fileId string) (err error) {
+	mock.record("DeleteFile", fileId)
+	if mock.DeleteFileFunc == nil {
+		return
+	}
+	return mock.DeleteFileFunc(context.Background(), fileId)
+}
+
+// Records a call to DeleteFileWithContext.
+func (mock *FileService) DeleteFileWithContext(ctx context.Context, fileId string) (err error) {
+	mock.record("DeleteFileWithContext", fileId)
+	if mock.DeleteFileFunc == nil {
+		return
+	}
+	return mock.DeleteFileFunc(ctx, fileId)
+}
+
+// Records a call to DeleteFiles.
+func (mock *FileService) DeleteFiles(fileIds []string) (deletedFileIds []string, err error) {
+	mock.record("DeleteFiles", fileIds)
+	if mock.DeleteFilesFunc == nil {
+		return
+	}
+	return mock.DeleteFilesFunc(context.Background(), fileIds)
+}
+
+// Records a call to DeleteFilesWithContext.
+func (mock *FileService) DeleteFilesWithContext(ctx context.Context, fileIds []string) (deletedFileIds []string, err error) {
+	mock.record("DeleteFilesWithContext", fileIds)
+	if mock.DeleteFilesFunc == nil {
+		return
+	}
+	return mock.DeleteFilesFunc(ctx, fileIds)
+}
+
+// Records a call to CopyFile.
+func (mock *FileService) CopyFile(srcFilePath string, destFolderPath string) (err error) {
+	mock.record("CopyFile", srcFilePath, destFolderPath)
+	if mock.CopyFileFunc == nil {
+		return
+	}
+	return mock.CopyFileFunc(context.Background(), srcFilePath, destFolderPath)
+}
+
+// Records a call to CopyFileWithContext.
+func (mock *FileService) CopyFileWithContext(ctx context.Context, srcFilePath string, destFolderPath string) (err error) {
+	mock.record("CopyFileWithContext", srcFilePath, destFolderPath)
+	if mock.CopyFileFunc == nil {
+		return
+	}
+	return mock.CopyFileFunc(ctx, srcFilePath, destFolderPath)
+}
+
+// Records a call to MoveFile.
+func (mock *FileService) MoveFile(srcFilePath string, destFolderPath string) (err error) {
+	mock.record("MoveFile", srcFilePath, destFolderPath)
+	if mock.MoveFileFunc == nil {
+		return
+	}
+	return mock.MoveFileFunc(context.Background(), srcFilePath, destFolderPath)
+}
+
+// Records a call to MoveFileWithContext.
+func (mock *FileService) MoveFileWithContext(ctx context.Context, srcFilePath string, destFolderPath string) (err error) {
+	mock.record("MoveFileWithContext", srcFilePath, destFolderPath)
+	if mock.MoveFileFunc == nil {
+		return
+	}
+	return mock.MoveFileFunc(ctx, srcFilePath, destFolderPath)
+}
+
+// Records a call to RenameFile.
+func (mock *FileService) RenameFile(srcFilePath string, newFileName string, purgeCache ...bool) (purgeRequestId string, err error) {
+	mock.record("RenameFile", srcFilePath, newFileName, purgeCache)
+	if mock.RenameFileFunc == nil {
+		return
+	}
+	return mock.RenameFileFunc(context.Background(), srcFilePath, newFileName, purgeCache...)
+}
+
+// Records a call to RenameFileWithContext.
+func (mock *FileService) RenameFileWithContext(ctx context.Context, srcFilePath string, newFileName string, purgeCache ...bool) (purgeRequestId string, err error) {
+	mock.record("RenameFileWithContext", srcFilePath, newFileName, purgeCache)
+	if mock.RenameFileFunc == nil {
+		return
+	}
+	return mock.RenameFileFunc(ctx, srcFilePath, newFileName, purgeCache...)
+}
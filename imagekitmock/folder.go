@@ -0,0 +1,90 @@
+package imagekitmock
+
+import (
+	"context"
+
+	imagekit "github.com/B3zaleel/imagekit-go"
+)
+
+var _ imagekit.FolderService = (*FolderService)(nil)
+
+// Represents a mock of the folder operations that records calls.
+type FolderService struct {
+	Recorder
+	CreateFolderFunc func(ctx context.Context, folderName string, parentFolderPath string) error
+	DeleteFolderFunc func(ctx context.Context, folderPath string) error
+	CopyFolderFunc   func(ctx context.Context, sourceFolderPath string, destinationPath string) (string, error)
+	MoveFolderFunc   func(ctx context.Context, sourceFolderPath string, destinationPath string) (string, error)
+}
+
+// Records a call to CreateFolder.
+func (mock *FolderService) CreateFolder(folderName string, parentFolderPath string) (err error) {
+	mock.record("CreateFolder", folderName, parentFolderPath)
+	if mock.CreateFolderFunc == nil {
+		return
+	}
+	return mock.CreateFolderFunc(context.Background(), folderName, parentFolderPath)
+}
+
+// Records a call to CreateFolderWithContext.
+func (mock *FolderService) CreateFolderWithContext(ctx context.Context, folderName string, parentFolderPath string) (err error) {
+	mock.record("CreateFolderWithContext", folderName, parentFolderPath)
+	if mock.CreateFolderFunc == nil {
+		return
+	}
+	return mock.CreateFolderFunc(ctx, folderName, parentFolderPath)
+}
+
+// Records a call to DeleteFolder.
+func (mock *FolderService) DeleteFolder(folderPath string) (err error) {
+	mock.record("DeleteFolder", folderPath)
+	if mock.DeleteFolderFunc == nil {
+		return
+	}
+	return mock.DeleteFolderFunc(context.Background(), folderPath)
+}
+
+// Records a call to DeleteFolderWithContext.
+func (mock *FolderService) DeleteFolderWithContext(ctx context.Context, folderPath string) (err error) {
+	mock.record("DeleteFolderWithContext", folderPath)
+	if mock.DeleteFolderFunc == nil {
+		return
+	}
+	return mock.DeleteFolderFunc(ctx, folderPath)
+}
+
+// Records a call to CopyFolder.
+func (mock *FolderService) CopyFolder(sourceFolderPath string, destinationPath string) (jobId string, err error) {
+	mock.record("CopyFolder", sourceFolderPath, destinationPath)
+	if mock.CopyFolderFunc == nil {
+		return
+	}
+	return mock.CopyFolderFunc(context.Background(), sourceFolderPath, destinationPath)
+}
+
+// Records a call to CopyFolderWithContext.
+func (mock *FolderService) CopyFolderWithContext(ctx context.Context, sourceFolderPath string, destinationPath string) (jobId string, err error) {
+	mock.record("CopyFolderWithContext", sourceFolderPath, destinationPath)
+	if mock.CopyFolderFunc == nil {
+		return
+	}
+	return mock.CopyFolderFunc(ctx, sourceFolderPath, destinationPath)
+}
+
+// Records a call to MoveFolder.
+func (mock *FolderService) MoveFolder(sourceFolderPath string, destinationPath string) (jobId string, err error) {
+	mock.record("MoveFolder", sourceFolderPath, destinationPath)
+	if mock.MoveFolderFunc == nil {
+		return
+	}
+	return mock.MoveFolderFunc(context.Background(), sourceFolderPath, destinationPath)
+}
+
+// Records a call to MoveFolderWithContext.
+func (mock *FolderService) MoveFolderWithContext(ctx context.Context, sourceFolderPath string, destinationPath string) (jobId string, err error) {
+	mock.record("MoveFolderWithContext", sourceFolderPath, destinationPath)
+	if mock.MoveFolderFunc == nil {
+		return
+	}
+	return mock.MoveFolderFunc(ctx, sourceFolderPath, destinationPath)
+}
@@ -0,0 +1,58 @@
+// Package imagekitmock provides mocks of the imagekit service interfaces
+// that record their calls.
+//
+// Each method of a mock is stubbed by the function field named after it,
+// such as GetFilesFunc, which also stubs its WithContext variant and is
+// called with context.Background() by the variant without a context. Calls
+// to methods whose function field is nil return zero values.
+package imagekitmock
+
+import "sync"
+
+// Represents a call to a mock method.
+type Call struct {
+	Method string
+	// The arguments of the call, excluding the context.
+	Args []interface{}
+}
+
+// Represents a record of the calls to a mock, which is safe for
+// concurrent use.
+type Recorder struct {
+	mutex sync.Mutex
+	calls []Call
+}
+
+// Adds a call to the record.
+func (recorder *Recorder) record(method string, args ...interface{}) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.calls = append(recorder.calls, Call{Method: method, Args: args})
+}
+
+// Gets all recorded calls in the order they were made.
+func (recorder *Recorder) Calls() []Call {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return append([]Call{}, recorder.calls...)
+}
+
+// Gets the recorded calls to a method.
+func (recorder *Recorder) CallsTo(method string) []Call {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	calls := []Call{}
+	for _, call := range recorder.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Removes all recorded calls.
+func (recorder *Recorder) Reset() {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.calls = nil
+}
@@ -0,0 +1,71 @@
+package imagekitmock
+
+import (
+	"context"
+
+	imagekit "github.com/B3zaleel/imagekit-go"
+)
+
+var _ imagekit.TagService = (*TagService)(nil)
+
+// Represents a mock of the tag operations that records calls.
+type TagService struct {
+	Recorder
+	AddTagsFunc      func(ctx context.Context, fileIds []string, tags []string) ([]string, error)
+	RemoveTagsFunc   func(ctx context.Context, fileIds []string, tags []string) ([]string, error)
+	RemoveAITagsFunc func(ctx context.Context, fileIds []string, aiTags []string) ([]string, error)
+}
+
+// Records a call to AddTags.
+func (mock *TagService) AddTags(fileIds []string, tags []string) (updatedFileIds []string, err error) {
+	mock.record("AddTags", fileIds, tags)
+	if mock.AddTagsFunc == nil {
+		return
+	}
+	return mock.AddTagsFunc(context.Background(), fileIds, tags)
+}
+
+// Records a call to AddTagsWithContext.
+func (mock *TagService) AddTagsWithContext(ctx context.Context, fileIds []string, tags []string) (updatedFileIds []string, err error) {
+	mock.record("AddTagsWithContext", fileIds, tags)
+	if mock.AddTagsFunc == nil {
+		return
+	}
+	return mock.AddTagsFunc(ctx, fileIds, tags)
+}
+
+// Records a call to RemoveTags.
+func (mock *TagService) RemoveTags(fileIds []string, tags []string) (updatedFileIds []string, err error) {
+	mock.record("RemoveTags", fileIds, tags)
+	if mock.RemoveTagsFunc == nil {
+		return
+	}
+	return mock.RemoveTagsFunc(context.Background(), fileIds, tags)
+}
+
+// Records a call to RemoveTagsWithContext.
+func (mock *TagService) RemoveTagsWithContext(ctx context.Context, fileIds []string, tags []string) (updatedFileIds []string, err error) {
+	mock.record("RemoveTagsWithContext", fileIds, tags)
+	if mock.RemoveTagsFunc == nil {
+		return
+	}
+	return mock.RemoveTagsFunc(ctx, fileIds, tags)
+}
+
+// Records a call to RemoveAITags.
+func (mock *TagService) RemoveAITags(fileIds []string, aiTags []string) (updatedFileIds []string, err error) {
+	mock.record("RemoveAITags", fileIds, aiTags)
+	if mock.RemoveAITagsFunc == nil {
+		return
+	}
+	return mock.RemoveAITagsFunc(context.Background(), fileIds, aiTags)
+}
+
+// Records a call to RemoveAITagsWithContext.
+func (mock *TagService) RemoveAITagsWithContext(ctx context.Context, fileIds []string, aiTags []string) (updatedFileIds []string, err error) {
+	mock.record("RemoveAITagsWithContext", fileIds, aiTags)
+	if mock.RemoveAITagsFunc == nil {
+		return
+	}
+	return mock.RemoveAITagsFunc(ctx, fileIds, aiTags)
+}
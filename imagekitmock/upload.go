@@ -0,0 +1,44 @@
+package imagekitmock
+
+import (
+	"context"
+	"io"
+
+	imagekit "github.com/B3zaleel/imagekit-go"
+)
+
+var _ imagekit.Uploader = (*Uploader)(nil)
+
+// Represents a mock of the upload operations that records calls.
+type Uploader struct {
+	Recorder
+	UploadFunc       func(ctx context.Context, file string, fileName string, options *imagekit.FileOptions) (*imagekit.FileDetails, error)
+	UploadReaderFunc func(ctx context.Context, reader io.Reader, fileName string, options *imagekit.FileOptions) (*imagekit.FileDetails, error)
+}
+
+// Records a call to Upload.
+func (mock *Uploader) Upload(file string, fileName string, options *imagekit.FileOptions) (result *imagekit.FileDetails, err error) {
+	mock.record("Upload", file, fileName, options)
+	if mock.UploadFunc == nil {
+		return
+	}
+	return mock.UploadFunc(context.Background(), file, fileName, options)
+}
+
+// Records a call to UploadWithContext.
+func (mock *Uploader) UploadWithContext(ctx context.Context, file string, fileName string, options *imagekit.FileOptions) (result *imagekit.FileDetails, err error) {
+	mock.record("UploadWithContext", file, fileName, options)
+	if mock.UploadFunc == nil {
+		return
+	}
+	return mock.UploadFunc(ctx, file, fileName, options)
+}
+
+// Records a call to UploadReader.
+func (mock *Uploader) UploadReader(ctx context.Context, reader io.Reader, fileName string, options *imagekit.FileOptions) (result *imagekit.FileDetails, err error) {
+	mock.record("UploadReader", reader, fileName, options)
+	if mock.UploadReaderFunc == nil {
+		return
+	}
+	return mock.UploadReaderFunc(ctx, reader, fileName, options)
+}
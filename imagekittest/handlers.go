@@ -0,0 +1,856 @@
+package imagekittest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	imagekit "github.com/B3zaleel/imagekit-go"
+)
+
+// Normalizes a folder path to start with and not end with a slash.
+func normalizePath(folderPath string) string {
+	folderPath = "/" + strings.Trim(folderPath, "/")
+	return path.Clean(folderPath)
+}
+
+// Joins a folder path and a name.
+func joinPath(folderPath, name string) string {
+	if folderPath == "/" {
+		return "/" + name
+	}
+	return folderPath + "/" + name
+}
+
+// Checks if a path is the folder path or inside it.
+func isInFolder(itemPath, folderPath string) bool {
+	return folderPath == "/" || itemPath == folderPath ||
+		strings.HasPrefix(itemPath, folderPath+"/")
+}
+
+// Sorts strings in increasing order.
+func sortStrings(strs []string) {
+	sort.Strings(strs)
+}
+
+// Decodes the JSON body of a request.
+func decodeJSON(r *http.Request, value interface{}) (err error) {
+	return json.NewDecoder(r.Body).Decode(value)
+}
+
+// Generates a new unique ID.
+func (server *Server) newId() string {
+	server.nextId++
+	return fmt.Sprintf("%024x", server.nextId)
+}
+
+// Creates a file, detecting its type and dimensions from its content.
+func (server *Server) newFile(name, folderPath string, content []byte) *file {
+	now := time.Now().UTC()
+	f := &file{
+		id:        server.newId(),
+		name:      name,
+		folder:    folderPath,
+		fileType:  "non-image",
+		mime:      mime.TypeByExtension(path.Ext(name)),
+		content:   content,
+		createdAt: now,
+		updatedAt: now,
+	}
+	if config, format, err := image.DecodeConfig(bytes.NewReader(content)); err == nil {
+		f.fileType = "image"
+		f.width, f.height = config.Width, config.Height
+		f.mime = "image/" + format
+	}
+	if len(f.mime) == 0 {
+		f.mime = "application/octet-stream"
+	}
+	server.ensureFolder(folderPath)
+	return f
+}
+
+// Creates a folder and its parents if they do not exist.
+func (server *Server) ensureFolder(folderPath string) {
+	for folderPath != "/" {
+		if _, ok := server.folders[folderPath]; ok {
+			return
+		}
+		server.folders[folderPath] = &folder{
+			id:        server.newId(),
+			path:      folderPath,
+			createdAt: time.Now().UTC(),
+		}
+		folderPath = path.Dir(folderPath)
+	}
+}
+
+// Converts a file to its JSON representation in API responses.
+func (server *Server) fileJSON(f *file) map[string]interface{} {
+	filePath := joinPath(f.folder, f.name)
+	fileUrl := server.URL + URL_ENDPOINT_PATH + filePath
+	thumbnail := ""
+	if f.fileType == "image" {
+		thumbnail = server.URL + URL_ENDPOINT_PATH + "/tr:n-ik_ml_thumbnail" + filePath
+	}
+	var tags interface{}
+	if len(f.tags) > 0 {
+		tags = f.tags
+	}
+	var aiTags interface{}
+	if len(f.aiTags) > 0 {
+		aiTags = f.aiTags
+	}
+	return map[string]interface{}{
+		"type":              "file",
+		"fileId":            f.id,
+		"name":              f.name,
+		"filePath":          filePath,
+		"tags":              tags,
+		"AITags":            aiTags,
+		"isPrivateFile":     f.isPrivate,
+		"customCoordinates": f.customCoordinates,
+		"url":               fileUrl,
+		"thumbnail":         thumbnail,
+		"fileType":          f.fileType,
+		"mime":              f.mime,
+		"height":            f.height,
+		"width":             f.width,
+		"size":              len(f.content),
+		"hasAlpha":          false,
+		"customMetadata":    f.customMetadata,
+		"createdAt":         f.createdAt.Format(time.RFC3339Nano),
+		"updatedAt":         f.updatedAt.Format(time.RFC3339Nano),
+	}
+}
+
+// Converts a file to its details.
+func (server *Server) fileDetails(f *file) imagekit.FileDetails {
+	details := imagekit.FileDetails{}
+	fileJSON, _ := json.Marshal(server.fileJSON(f))
+	json.Unmarshal(fileJSON, &details)
+	return details
+}
+
+// Gets the files inside a folder ordered by creation time.
+func (server *Server) sortedFiles(folderPath string) []*file {
+	files := []*file{}
+	for _, f := range server.files {
+		if len(folderPath) == 0 || isInFolder(f.folder, folderPath) {
+			files = append(files, f)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].id < files[j].id
+	})
+	return files
+}
+
+// Finds a file by its path.
+func (server *Server) findFileByPath(filePath string) *file {
+	filePath = normalizePath(filePath)
+	for _, f := range server.files {
+		if joinPath(f.folder, f.name) == filePath {
+			return f
+		}
+	}
+	return nil
+}
+
+// Finds a file by the path of its URL under the URL endpoint, ignoring
+// transformations.
+func (server *Server) findFileByUrlPath(urlPath string) *file {
+	segments := []string{}
+	for _, segment := range strings.Split(strings.TrimPrefix(urlPath, URL_ENDPOINT_PATH), "/") {
+		if len(segment) > 0 && !strings.HasPrefix(segment, "tr:") {
+			segments = append(segments, segment)
+		}
+	}
+	return server.findFileByPath("/" + strings.Join(segments, "/"))
+}
+
+// Serves the content of a file from the URL endpoint.
+func (server *Server) serveFileContent(w http.ResponseWriter, r *http.Request) {
+	server.mutex.Lock()
+	f := server.findFileByUrlPath(r.URL.Path)
+	var content []byte
+	mimeType := ""
+	if f != nil {
+		content, mimeType = f.content, f.mime
+	}
+	server.mutex.Unlock()
+	if f == nil {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", mimeType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+	w.Write(content)
+}
+
+// Represents an entry of a file listing.
+type listEntry struct {
+	name                 string
+	createdAt, updatedAt time.Time
+	width, height, size  int
+	value                map[string]interface{}
+}
+
+// Lists files and folders.
+func (server *Server) handleListFiles(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	listType := query.Get("type")
+	if len(listType) == 0 {
+		listType = "all"
+	}
+	fileType := query.Get("fileType")
+	if len(fileType) == 0 {
+		fileType = "all"
+	}
+	folderPath := "/"
+	if len(query.Get("path")) > 0 {
+		folderPath = normalizePath(query.Get("path"))
+	}
+	tags := map[string]bool{}
+	for _, tag := range strings.Split(query.Get("tags"), ",") {
+		if len(tag) > 0 {
+			tags[tag] = true
+		}
+	}
+	limit, skip := imagekit.MAX_LIMIT_VALUE, 0
+	var err error
+	if len(query.Get("limit")) > 0 {
+		limit, err = strconv.Atoi(query.Get("limit"))
+		if err != nil || limit < imagekit.MIN_LIMIT_VALUE || limit > imagekit.MAX_LIMIT_VALUE {
+			writeError(w, http.StatusBadRequest, "Invalid limit.")
+			return
+		}
+	}
+	if len(query.Get("skip")) > 0 {
+		skip, err = strconv.Atoi(query.Get("skip"))
+		if err != nil || skip < imagekit.MIN_SKIP_VALUE {
+			writeError(w, http.StatusBadRequest, "Invalid skip.")
+			return
+		}
+	}
+	server.mutex.Lock()
+	entries := []listEntry{}
+	if listType == "all" || listType == "file" {
+		for _, f := range server.sortedFiles(folderPath) {
+			if fileType != "all" && fileType != f.fileType {
+				continue
+			}
+			if len(tags) > 0 {
+				hasTag := false
+				for _, tag := range f.tags {
+					hasTag = hasTag || tags[tag]
+				}
+				if !hasTag {
+					continue
+				}
+			}
+			entries = append(entries, listEntry{
+				name:      f.name,
+				createdAt: f.createdAt,
+				updatedAt: f.updatedAt,
+				width:     f.width,
+				height:    f.height,
+				size:      len(f.content),
+				value:     server.fileJSON(f),
+			})
+		}
+	}
+	if (listType == "all" || listType == "folder") && len(tags) == 0 {
+		for _, fo := range server.folders {
+			if fo.path == folderPath || !isInFolder(fo.path, folderPath) {
+				continue
+			}
+			entries = append(entries, listEntry{
+				name:      path.Base(fo.path),
+				createdAt: fo.createdAt,
+				updatedAt: fo.createdAt,
+				value: map[string]interface{}{
+					"type":       "folder",
+					"folderId":   fo.id,
+					"name":       path.Base(fo.path),
+					"folderPath": fo.path,
+					"createdAt":  fo.createdAt.Format(time.RFC3339Nano),
+					"updatedAt":  fo.createdAt.Format(time.RFC3339Nano),
+				},
+			})
+		}
+	}
+	server.mutex.Unlock()
+	sortEntries(entries, query.Get("sort"))
+	values := []map[string]interface{}{}
+	for i := skip; i < len(entries) && i < skip+limit; i++ {
+		values = append(values, entries[i].value)
+	}
+	writeJSON(w, http.StatusOK, values)
+}
+
+// Sorts entries of a file listing by a sort value such as "DESC_SIZE".
+func sortEntries(entries []listEntry, sortValue string) {
+	if len(sortValue) == 0 {
+		sortValue = "ASC_CREATED"
+	}
+	descending := strings.HasPrefix(sortValue, "DESC_")
+	field := strings.TrimPrefix(strings.TrimPrefix(sortValue, "DESC_"), "ASC_")
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if descending {
+			a, b = b, a
+		}
+		switch field {
+		case "NAME":
+			return a.name < b.name
+		case "UPDATED":
+			return a.updatedAt.Before(b.updatedAt)
+		case "HEIGHT":
+			return a.height < b.height
+		case "WIDTH":
+			return a.width < b.width
+		case "SIZE":
+			return a.size < b.size
+		}
+		return a.createdAt.Before(b.createdAt)
+	})
+}
+
+// Reads the content of a file from its URL, which must be under the URL
+// endpoint of the server, so that no request leaves the fake.
+func (server *Server) readFileUrl(fileUrl *url.URL) (content []byte, err error) {
+	serverUrl, err := url.Parse(server.URL)
+	if err != nil {
+		return nil, err
+	}
+	if fileUrl.Host != serverUrl.Host || !strings.HasPrefix(fileUrl.Path, URL_ENDPOINT_PATH+"/") {
+		return nil, fmt.Errorf("file url must be under the url endpoint of the fake server")
+	}
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	f := server.findFileByUrlPath(fileUrl.Path)
+	if f == nil {
+		return nil, fmt.Errorf("failed to fetch file from url")
+	}
+	return append([]byte{}, f.content...), nil
+}
+
+// Reads the file of an upload request from a file part, a URL under the
+// URL endpoint or base64.
+func (server *Server) readUploadFile(r *http.Request) (content []byte, err error) {
+	if fileHeaders := r.MultipartForm.File["file"]; len(fileHeaders) > 0 {
+		uploadedFile, err := fileHeaders[0].Open()
+		if err != nil {
+			return nil, err
+		}
+		defer uploadedFile.Close()
+		return io.ReadAll(uploadedFile)
+	}
+	value := r.FormValue("file")
+	if len(value) == 0 {
+		return nil, fmt.Errorf("missing file parameter")
+	}
+	if fileUrl, err := url.Parse(value); err == nil &&
+		(fileUrl.Scheme == "http" || fileUrl.Scheme == "https") {
+		return server.readFileUrl(fileUrl)
+	}
+	if content, err = base64.StdEncoding.DecodeString(value); err == nil {
+		return content, nil
+	}
+	if content, err = base64.RawStdEncoding.DecodeString(value); err == nil {
+		return content, nil
+	}
+	return nil, fmt.Errorf("invalid file parameter")
+}
+
+// Uploads a file.
+func (server *Server) handleUpload(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid multipart body.")
+		return
+	}
+	content, err := server.readUploadFile(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	fileName := r.FormValue("fileName")
+	if len(fileName) == 0 {
+		writeError(w, http.StatusBadRequest, "Missing fileName parameter.")
+		return
+	}
+	folderPath := normalizePath(r.FormValue("folder"))
+	if r.FormValue("useUniqueFileName") != "false" {
+		ext := path.Ext(fileName)
+		fileName = fmt.Sprintf(
+			"%s_%s%s",
+			strings.TrimSuffix(fileName, ext),
+			strconv.FormatInt(time.Now().UnixNano()%1e9, 36),
+			ext,
+		)
+	}
+	var customMetadata interface{}
+	if value := r.FormValue("customMetadata"); len(value) > 0 {
+		if err = json.Unmarshal([]byte(value), &customMetadata); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid customMetadata.")
+			return
+		}
+	}
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	existing := server.findFileByPath(joinPath(folderPath, fileName))
+	if existing != nil && r.FormValue("overwriteFile") == "false" {
+		writeError(w, http.StatusBadRequest, "A file with the same name already exists.")
+		return
+	}
+	f := server.newFile(fileName, folderPath, content)
+	if existing != nil {
+		f.id, f.createdAt = existing.id, existing.createdAt
+	}
+	for _, tag := range strings.Split(r.FormValue("tags"), ",") {
+		if len(tag) > 0 {
+			f.tags = append(f.tags, tag)
+		}
+	}
+	f.isPrivate = r.FormValue("isPrivateFile") == "true"
+	if value := r.FormValue("customCoordinates"); len(value) > 0 {
+		f.customCoordinates = &value
+	}
+	f.customMetadata = customMetadata
+	server.files[f.id] = f
+	writeJSON(w, http.StatusOK, server.fileJSON(f))
+}
+
+// Gets the details of a file.
+func (server *Server) handleGetFileDetails(w http.ResponseWriter, fileId string) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	f, ok := server.files[fileId]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested file does not exist.")
+		return
+	}
+	writeJSON(w, http.StatusOK, server.fileJSON(f))
+}
+
+// Updates the details of a file.
+func (server *Server) handleUpdateFileDetails(
+	w http.ResponseWriter,
+	r *http.Request,
+	fileId string) {
+	reqBody := struct {
+		Tags              *[]string    `json:"tags"`
+		CustomCoordinates *string      `json:"customCoordinates"`
+		CustomMetadata    *interface{} `json:"customMetadata"`
+	}{}
+	if err := decodeJSON(r, &reqBody); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body.")
+		return
+	}
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	f, ok := server.files[fileId]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested file does not exist.")
+		return
+	}
+	if reqBody.Tags != nil {
+		f.tags = *reqBody.Tags
+	}
+	if reqBody.CustomCoordinates != nil {
+		f.customCoordinates = reqBody.CustomCoordinates
+	}
+	if reqBody.CustomMetadata != nil {
+		current, isCurrentMap := f.customMetadata.(map[string]interface{})
+		update, isUpdateMap := (*reqBody.CustomMetadata).(map[string]interface{})
+		if isCurrentMap && isUpdateMap {
+			for key, value := range update {
+				current[key] = value
+			}
+		} else {
+			f.customMetadata = *reqBody.CustomMetadata
+		}
+	}
+	f.updatedAt = time.Now().UTC()
+	writeJSON(w, http.StatusOK, server.fileJSON(f))
+}
+
+// Gets the metadata of a file. The pHash is computed with imagekit.PHash,
+// which is deterministic but differs from the pHash computed by the API.
+func (server *Server) handleGetFileMetadata(w http.ResponseWriter, fileId string) {
+	server.mutex.Lock()
+	f, ok := server.files[fileId]
+	var content []byte
+	if ok {
+		content = f.content
+	}
+	server.mutex.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "The requested file does not exist.")
+		return
+	}
+	img, format, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "The requested file is not an image.")
+		return
+	}
+	pHash, err := imagekit.PHash(img)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"height":          img.Bounds().Dy(),
+		"width":           img.Bounds().Dx(),
+		"size":            len(content),
+		"format":          format,
+		"hasColorProfile": false,
+		"quality":         0,
+		"density":         72,
+		"hasTransparency": false,
+		"pHash":           pHash,
+	})
+}
+
+// Deletes a file.
+func (server *Server) handleDeleteFile(w http.ResponseWriter, fileId string) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	if _, ok := server.files[fileId]; !ok {
+		writeError(w, http.StatusNotFound, "The requested file does not exist.")
+		return
+	}
+	delete(server.files, fileId)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Copies or moves a file to a folder.
+func (server *Server) transferFile(w http.ResponseWriter, r *http.Request, move bool) {
+	reqBody := struct {
+		SourceFilePath  string `json:"sourceFilePath"`
+		DestinationPath string `json:"destinationPath"`
+	}{}
+	if err := decodeJSON(r, &reqBody); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body.")
+		return
+	}
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	source := server.findFileByPath(reqBody.SourceFilePath)
+	if source == nil {
+		writeError(w, http.StatusNotFound, "No file found with filePath "+reqBody.SourceFilePath)
+		return
+	}
+	destinationPath := normalizePath(reqBody.DestinationPath)
+	if existing := server.findFileByPath(joinPath(destinationPath, source.name)); existing != nil && existing != source {
+		delete(server.files, existing.id)
+	}
+	if move {
+		server.ensureFolder(destinationPath)
+		source.folder = destinationPath
+		source.updatedAt = time.Now().UTC()
+	} else {
+		copied := server.newFile(source.name, destinationPath, source.content)
+		copied.tags = append([]string{}, source.tags...)
+		copied.customMetadata = source.customMetadata
+		server.files[copied.id] = copied
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Copies a file to a folder.
+func (server *Server) handleCopyFile(w http.ResponseWriter, r *http.Request) {
+	server.transferFile(w, r, false)
+}
+
+// Moves a file to a folder.
+func (server *Server) handleMoveFile(w http.ResponseWriter, r *http.Request) {
+	server.transferFile(w, r, true)
+}
+
+// Renames a file.
+func (server *Server) handleRenameFile(w http.ResponseWriter, r *http.Request) {
+	reqBody := struct {
+		FilePath    string `json:"filePath"`
+		NewFileName string `json:"newFileName"`
+		PurgeCache  bool   `json:"purgeCache"`
+	}{}
+	if err := decodeJSON(r, &reqBody); err != nil || len(reqBody.NewFileName) == 0 {
+		writeError(w, http.StatusBadRequest, "Invalid request body.")
+		return
+	}
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	f := server.findFileByPath(reqBody.FilePath)
+	if f == nil {
+		writeError(w, http.StatusNotFound, "No file found with filePath "+reqBody.FilePath)
+		return
+	}
+	if server.findFileByPath(joinPath(f.folder, reqBody.NewFileName)) != nil {
+		writeError(w, http.StatusConflict, "File with the new name already exists.")
+		return
+	}
+	f.name = reqBody.NewFileName
+	f.updatedAt = time.Now().UTC()
+	response := map[string]string{}
+	if reqBody.PurgeCache {
+		requestId := server.newId()
+		server.purges[requestId] = imagekit.PURGE_STATUS_COMPLETED
+		response["purgeRequestId"] = requestId
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Purges the cache of a URL.
+func (server *Server) handlePurgeCache(w http.ResponseWriter, r *http.Request) {
+	reqBody := struct {
+		FileUrl string `json:"fileUrl"`
+	}{}
+	if err := decodeJSON(r, &reqBody); err != nil || len(reqBody.FileUrl) == 0 {
+		writeError(w, http.StatusBadRequest, "Invalid request body.")
+		return
+	}
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	requestId := server.newId()
+	server.purges[requestId] = imagekit.PURGE_STATUS_COMPLETED
+	writeJSON(w, http.StatusCreated, map[string]string{"requestId": requestId})
+}
+
+// Gets the status of a cache purge.
+func (server *Server) handleGetPurgeCacheStatus(w http.ResponseWriter, requestId string) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	status, ok := server.purges[requestId]
+	if !ok {
+		writeError(w, http.StatusNotFound, "No request found for this requestId.")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": status})
+}
+
+// Finds the IDs that do not belong to any file.
+func (server *Server) missingFileIds(fileIds []string) []string {
+	missing := []string{}
+	for _, fileId := range fileIds {
+		if _, ok := server.files[fileId]; !ok {
+			missing = append(missing, fileId)
+		}
+	}
+	return missing
+}
+
+// Adds or removes tags or AI tags of files.
+func (server *Server) handleUpdateTags(
+	w http.ResponseWriter,
+	r *http.Request,
+	key string,
+	add bool) {
+	reqBody := make(map[string][]string)
+	if err := decodeJSON(r, &reqBody); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body.")
+		return
+	}
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	fileIds := reqBody["fileIds"]
+	if missing := server.missingFileIds(fileIds); len(missing) > 0 {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{
+			"message":        "The requested file(s) does not exist.",
+			"missingFileIds": missing,
+		})
+		return
+	}
+	for _, fileId := range fileIds {
+		f := server.files[fileId]
+		for _, tag := range reqBody[key] {
+			if key == "AITags" {
+				aiTags := []interface{}{}
+				for _, aiTag := range f.aiTags {
+					if tagMap, ok := aiTag.(map[string]interface{}); !ok || tagMap["name"] != tag {
+						aiTags = append(aiTags, aiTag)
+					}
+				}
+				f.aiTags = aiTags
+				continue
+			}
+			tags := []string{}
+			for _, existing := range f.tags {
+				if existing != tag {
+					tags = append(tags, existing)
+				}
+			}
+			if add {
+				tags = append(tags, tag)
+			}
+			f.tags = tags
+		}
+		f.updatedAt = time.Now().UTC()
+	}
+	writeJSON(w, http.StatusOK, map[string][]string{
+		"successfullyUpdatedFileIds": fileIds,
+	})
+}
+
+// Deletes files by their IDs.
+func (server *Server) handleDeleteFiles(w http.ResponseWriter, r *http.Request) {
+	reqBody := make(map[string][]string)
+	if err := decodeJSON(r, &reqBody); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body.")
+		return
+	}
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	fileIds := reqBody["fileIds"]
+	if missing := server.missingFileIds(fileIds); len(missing) > 0 {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{
+			"message":        "The requested file(s) does not exist.",
+			"missingFileIds": missing,
+		})
+		return
+	}
+	for _, fileId := range fileIds {
+		delete(server.files, fileId)
+	}
+	writeJSON(w, http.StatusOK, map[string][]string{
+		"successfullyDeletedFileIds": fileIds,
+	})
+}
+
+// Creates a folder.
+func (server *Server) handleCreateFolder(w http.ResponseWriter, r *http.Request) {
+	reqBody := struct {
+		FolderName       string `json:"folderName"`
+		ParentFolderPath string `json:"parentFolderPath"`
+	}{}
+	if err := decodeJSON(r, &reqBody); err != nil || len(strings.Trim(reqBody.FolderName, "/")) == 0 {
+		writeError(w, http.StatusBadRequest, "Invalid request body.")
+		return
+	}
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.ensureFolder(joinPath(
+		normalizePath(reqBody.ParentFolderPath),
+		strings.Trim(reqBody.FolderName, "/"),
+	))
+	writeJSON(w, http.StatusCreated, map[string]string{})
+}
+
+// Deletes a folder and everything inside it.
+func (server *Server) handleDeleteFolder(w http.ResponseWriter, r *http.Request) {
+	reqBody := struct {
+		FolderPath string `json:"folderPath"`
+	}{}
+	if err := decodeJSON(r, &reqBody); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body.")
+		return
+	}
+	folderPath := normalizePath(reqBody.FolderPath)
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	if _, ok := server.folders[folderPath]; !ok {
+		writeError(w, http.StatusNotFound, "No folder found with folderPath "+folderPath)
+		return
+	}
+	for existingPath := range server.folders {
+		if isInFolder(existingPath, folderPath) {
+			delete(server.folders, existingPath)
+		}
+	}
+	for fileId, f := range server.files {
+		if isInFolder(f.folder, folderPath) {
+			delete(server.files, fileId)
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Copies or moves a folder into another folder as a bulk job.
+func (server *Server) handleCopyFolder(w http.ResponseWriter, r *http.Request, move bool) {
+	reqBody := struct {
+		SourceFolderPath string `json:"sourceFolderPath"`
+		DestinationPath  string `json:"destinationPath"`
+	}{}
+	if err := decodeJSON(r, &reqBody); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body.")
+		return
+	}
+	sourcePath := normalizePath(reqBody.SourceFolderPath)
+	destinationPath := joinPath(normalizePath(reqBody.DestinationPath), path.Base(sourcePath))
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	if _, ok := server.folders[sourcePath]; !ok {
+		writeError(w, http.StatusNotFound, "No files & folder found at sourceFolderPath "+sourcePath)
+		return
+	}
+	if isInFolder(destinationPath, sourcePath) {
+		writeError(w, http.StatusBadRequest, "Destination must not be inside the source folder.")
+		return
+	}
+	relocate := func(itemPath string) string {
+		return destinationPath + strings.TrimPrefix(itemPath, sourcePath)
+	}
+	for existingPath := range server.folders {
+		if isInFolder(existingPath, sourcePath) {
+			server.ensureFolder(relocate(existingPath))
+			if move {
+				delete(server.folders, existingPath)
+			}
+		}
+	}
+	for _, f := range server.sortedFiles(sourcePath) {
+		if move {
+			f.folder = relocate(f.folder)
+			continue
+		}
+		copied := server.newFile(f.name, relocate(f.folder), f.content)
+		copied.tags = append([]string{}, f.tags...)
+		copied.customMetadata = f.customMetadata
+		server.files[copied.id] = copied
+	}
+	jobType := "COPY_FOLDER"
+	if move {
+		jobType = "MOVE_FOLDER"
+	}
+	jobId := server.newId()
+	server.jobs[jobId] = &bulkJob{
+		details: imagekit.JobDetails{
+			JobId:  imagekit.String(jobId),
+			Type:   imagekit.String(jobType),
+			Status: imagekit.JOB_STATUS_PENDING,
+		},
+		pendingPolls: server.JobPendingPolls,
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"jobId": jobId})
+}
+
+// Gets the status of a bulk job.
+func (server *Server) handleGetBulkJobStatus(w http.ResponseWriter, jobId string) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	job, ok := server.jobs[jobId]
+	if !ok {
+		writeError(w, http.StatusNotFound, "No job found with jobId "+jobId)
+		return
+	}
+	if job.pendingPolls > 0 {
+		job.pendingPolls--
+	} else {
+		job.details.Status = imagekit.JOB_STATUS_COMPLETED
+	}
+	writeJSON(w, http.StatusOK, job.details)
+}
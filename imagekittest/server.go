@@ -0,0 +1,352 @@
+// Package imagekittest provides an in-memory fake of the ImageKit.io API
+// for testing code that uses imagekit-go.
+package imagekittest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	imagekit "github.com/B3zaleel/imagekit-go"
+)
+
+const (
+	PUBLIC_KEY        = "public_test"
+	PRIVATE_KEY       = "private_test"
+	API_PATH          = "/v1"
+	UPLOAD_PATH       = "/api/v1/files/upload"
+	URL_ENDPOINT_PATH = "/endpoint"
+)
+
+// Represents an error response injected into requests matching a method
+// and path prefix.
+type Fault struct {
+	// The method of matching requests, where empty matches any method.
+	Method string
+	// The path prefix of matching requests, such as "/v1/files", where
+	// empty matches any path.
+	PathPrefix string
+	// The status code of the response, where 0 only adds Latency.
+	StatusCode int
+	Headers    map[string]string
+	Body       string
+	// The time to wait before responding.
+	Latency time.Duration
+	// The number of matching requests to fail, where 0 fails all of them.
+	Times int
+}
+
+// Represents a request received by the fake server.
+type Request struct {
+	Method, Path, RawQuery string
+}
+
+// Represents a stored file.
+type file struct {
+	id                string
+	name              string
+	folder            string
+	tags              []string
+	aiTags            []interface{}
+	isPrivate         bool
+	customCoordinates *string
+	customMetadata    interface{}
+	fileType          string
+	mime              string
+	width, height     int
+	content           []byte
+	createdAt         time.Time
+	updatedAt         time.Time
+}
+
+// Represents a stored folder.
+type folder struct {
+	id        string
+	path      string
+	createdAt time.Time
+}
+
+// Represents a stored bulk job.
+type bulkJob struct {
+	details      imagekit.JobDetails
+	pendingPolls int
+}
+
+// Represents a fake ImageKit.io API server with in-memory state.
+type Server struct {
+	URL string
+	// The number of status checks for which a bulk job is reported as
+	// pending before it is completed.
+	JobPendingPolls int
+	server          *httptest.Server
+	mutex           sync.Mutex
+	latency         time.Duration
+	faults          []*Fault
+	requests        []Request
+	files           map[string]*file
+	folders         map[string]*folder
+	jobs            map[string]*bulkJob
+	purges          map[string]string
+	nextId          int
+}
+
+// Creates and starts a fake server. It must be closed when done.
+func NewServer() *Server {
+	server := &Server{
+		JobPendingPolls: 1,
+		files:           make(map[string]*file),
+		folders:         make(map[string]*folder),
+		jobs:            make(map[string]*bulkJob),
+		purges:          make(map[string]string),
+	}
+	server.server = httptest.NewServer(server)
+	server.URL = server.server.URL
+	return server
+}
+
+// Shuts the server down.
+func (server *Server) Close() {
+	server.server.Close()
+}
+
+// Creates an ImageKit instance that sends its requests to the server.
+func (server *Server) ImageKit(options ...imagekit.Option) *imagekit.ImageKit {
+	options = append([]imagekit.Option{
+		imagekit.WithHttpClient(server.server.Client()),
+		imagekit.WithBaseUrl(server.URL + API_PATH),
+		imagekit.WithUploadUrl(server.URL + UPLOAD_PATH),
+	}, options...)
+	return imagekit.New(
+		PUBLIC_KEY,
+		PRIVATE_KEY,
+		server.URL+URL_ENDPOINT_PATH,
+		options...,
+	)
+}
+
+// Sets the time to wait before responding to every request.
+func (server *Server) SetLatency(latency time.Duration) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.latency = latency
+}
+
+// Adds a fault to inject into matching requests. Faults are matched in the
+// order they were added.
+func (server *Server) InjectFault(fault Fault) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.faults = append(server.faults, &fault)
+}
+
+// Removes all faults.
+func (server *Server) ClearFaults() {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.faults = nil
+}
+
+// Gets the requests received by the server.
+func (server *Server) Requests() []Request {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return append([]Request{}, server.requests...)
+}
+
+// Adds a file to the media library, returning its details.
+func (server *Server) AddFile(
+	name,
+	folderPath string,
+	content []byte) imagekit.FileDetails {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	f := server.newFile(name, normalizePath(folderPath), content)
+	server.files[f.id] = f
+	return server.fileDetails(f)
+}
+
+// Gets the details of all files in the media library.
+func (server *Server) Files() []imagekit.FileDetails {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	files := []imagekit.FileDetails{}
+	for _, f := range server.sortedFiles("") {
+		files = append(files, server.fileDetails(f))
+	}
+	return files
+}
+
+// Gets the content of a file.
+func (server *Server) FileContent(fileId string) (content []byte, ok bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	f, ok := server.files[fileId]
+	if !ok {
+		return nil, false
+	}
+	return append([]byte{}, f.content...), true
+}
+
+// Gets the paths of all folders in the media library.
+func (server *Server) Folders() []string {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	paths := []string{}
+	for path := range server.folders {
+		paths = append(paths, path)
+	}
+	sortStrings(paths)
+	return paths
+}
+
+// Finds the first fault matching a request, consuming one of its uses.
+func (server *Server) matchFault(r *http.Request) (fault Fault, ok bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	for i, f := range server.faults {
+		if len(f.Method) > 0 && f.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, f.PathPrefix) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				server.faults = append(server.faults[:i], server.faults[i+1:]...)
+			}
+		}
+		return *f, true
+	}
+	return Fault{}, false
+}
+
+// Handles a request to the fake API.
+func (server *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	server.mutex.Lock()
+	server.requests = append(server.requests, Request{
+		Method:   r.Method,
+		Path:     r.URL.Path,
+		RawQuery: r.URL.RawQuery,
+	})
+	latency := server.latency
+	server.mutex.Unlock()
+	fault, hasFault := server.matchFault(r)
+	if hasFault {
+		latency += fault.Latency
+	}
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if hasFault && fault.StatusCode != 0 {
+		for key, value := range fault.Headers {
+			w.Header().Set(key, value)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(fault.StatusCode)
+		body := fault.Body
+		if len(body) == 0 {
+			body = fmt.Sprintf(`{"message": %q}`, http.StatusText(fault.StatusCode))
+		}
+		w.Write([]byte(body))
+		return
+	}
+	if strings.HasPrefix(r.URL.Path, URL_ENDPOINT_PATH+"/") {
+		server.serveFileContent(w, r)
+		return
+	}
+	if username, _, ok := r.BasicAuth(); !ok || username != PRIVATE_KEY {
+		writeError(w, http.StatusUnauthorized, "Your account cannot be authenticated.")
+		return
+	}
+	if r.URL.Path == UPLOAD_PATH {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed.")
+			return
+		}
+		server.handleUpload(w, r)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, API_PATH+"/") {
+		writeError(w, http.StatusNotFound, "Not found.")
+		return
+	}
+	server.route(w, r, strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, API_PATH), "/"), "/"))
+}
+
+// Dispatches an API request to its handler.
+func (server *Server) route(w http.ResponseWriter, r *http.Request, segments []string) {
+	route := fmt.Sprintf("%s %s", r.Method, strings.Join(segments, "/"))
+	switch {
+	case route == "GET files":
+		server.handleListFiles(w, r)
+	case route == "POST files/copy":
+		server.handleCopyFile(w, r)
+	case route == "POST files/move":
+		server.handleMoveFile(w, r)
+	case route == "PUT files/rename":
+		server.handleRenameFile(w, r)
+	case route == "POST files/purge":
+		server.handlePurgeCache(w, r)
+	case r.Method == http.MethodGet && len(segments) == 3 &&
+		segments[0] == "files" && segments[1] == "purge":
+		server.handleGetPurgeCacheStatus(w, segments[2])
+	case route == "POST files/addTags":
+		server.handleUpdateTags(w, r, "tags", true)
+	case route == "POST files/removeTags":
+		server.handleUpdateTags(w, r, "tags", false)
+	case route == "POST files/removeAITags":
+		server.handleUpdateTags(w, r, "AITags", false)
+	case route == "POST files/batch/deleteByFileIds":
+		server.handleDeleteFiles(w, r)
+	case len(segments) == 3 && segments[0] == "files" && segments[2] == "details":
+		switch r.Method {
+		case http.MethodGet:
+			server.handleGetFileDetails(w, segments[1])
+		case http.MethodPatch:
+			server.handleUpdateFileDetails(w, r, segments[1])
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed.")
+		}
+	case r.Method == http.MethodGet && len(segments) == 3 &&
+		segments[0] == "files" && segments[2] == "metadata":
+		server.handleGetFileMetadata(w, segments[1])
+	case r.Method == http.MethodDelete && len(segments) == 2 && segments[0] == "files":
+		server.handleDeleteFile(w, segments[1])
+	case route == "POST folder":
+		server.handleCreateFolder(w, r)
+	case route == "DELETE folder":
+		server.handleDeleteFolder(w, r)
+	case route == "POST bulkJobs/copyFolder":
+		server.handleCopyFolder(w, r, false)
+	case route == "POST bulkJobs/moveFolder":
+		server.handleCopyFolder(w, r, true)
+	case r.Method == http.MethodGet && len(segments) == 2 && segments[0] == "bulkJobs":
+		server.handleGetBulkJobStatus(w, segments[1])
+	default:
+		writeError(w, http.StatusNotFound, "Not found.")
+	}
+}
+
+// Writes a JSON response.
+func writeJSON(w http.ResponseWriter, statusCode int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(value)
+}
+
+// Writes an error response in the format of the API.
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]string{
+		"message": message,
+		"help":    "For support kindly contact us at support@imagekit.io .",
+	})
+}
@@ -0,0 +1,158 @@
+package imagekittest_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	imagekit "github.com/B3zaleel/imagekit-go"
+	"github.com/B3zaleel/imagekit-go/imagekittest"
+)
+
+func TestServerRouting(t *testing.T) {
+	server := imagekittest.NewServer()
+	defer server.Close()
+	imgKit := server.ImageKit()
+	folder, useUniqueFileName := imagekit.String("/marketing"), imagekit.Bool(false)
+	uploaded, err := imgKit.UploadReader(context.Background(), strings.NewReader("banner"), "banner.txt",
+		&imagekit.FileOptions{Folder: &folder, UseUniqueFileName: &useUniqueFileName})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(*uploaded.FilePath) != "/marketing/banner.txt" {
+		t.Errorf("FilePath = %s", *uploaded.FilePath)
+	}
+	details, err := imgKit.GetFileDetails(string(*uploaded.FileId))
+	if err != nil || string(*details.Name) != "banner.txt" {
+		t.Fatalf("GetFileDetails = %+v, %v", details, err)
+	}
+	res, err := http.Get(string(*details.Url))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK || string(content) != "banner" {
+		t.Errorf("content at %s = %d %q", *details.Url, res.StatusCode, content)
+	}
+	if err = imgKit.DeleteFile(string(*uploaded.FileId)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = imgKit.GetFileDetails(string(*uploaded.FileId)); !imagekit.IsNotFound(err) {
+		t.Errorf("GetFileDetails of a deleted file = %v, want a not found error", err)
+	}
+	unauthenticated := imagekit.New(imagekittest.PUBLIC_KEY, "wrong_key", server.URL+imagekittest.URL_ENDPOINT_PATH,
+		imagekit.WithBaseUrl(server.URL+imagekittest.API_PATH))
+	if _, err = unauthenticated.GetFiles(&imagekit.FilesFetchParams{}); !imagekit.IsUnauthorized(err) {
+		t.Errorf("GetFiles with a wrong key = %v, want an unauthorized error", err)
+	}
+	req, err := http.NewRequest(http.MethodGet, server.URL+imagekittest.API_PATH+"/unknown", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetBasicAuth(imagekittest.PRIVATE_KEY, "")
+	if _, err = imgKit.DoRequest(req); !imagekit.IsNotFound(err) {
+		t.Errorf("unknown route = %v, want a not found error", err)
+	}
+}
+
+func TestServerUrlUploads(t *testing.T) {
+	server := imagekittest.NewServer()
+	defer server.Close()
+	imgKit := server.ImageKit(imagekit.WithRetryPolicy(imagekit.NoRetryPolicy))
+	source := server.AddFile("source.txt", "/", []byte("source"))
+	copied, err := imgKit.Upload(string(*source.Url), "copy.txt", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content, _ := server.FileContent(string(*copied.FileId)); !bytes.Equal(content, []byte("source")) {
+		t.Errorf("content = %q, want %q", content, "source")
+	}
+	for _, fileUrl := range []string{
+		"https://example.com/image.jpg",
+		server.URL + imagekittest.API_PATH + "/files",
+		server.URL + imagekittest.URL_ENDPOINT_PATH + "/missing.txt",
+	} {
+		if _, err := imgKit.Upload(fileUrl, "remote.txt", nil); !imagekit.IsBadRequest(err) {
+			t.Errorf("Upload(%s) = %v, want a bad request error", fileUrl, err)
+		}
+	}
+}
+
+func TestServerFaultTimes(t *testing.T) {
+	server := imagekittest.NewServer()
+	defer server.Close()
+	imgKit := server.ImageKit(imagekit.WithRetryPolicy(imagekit.NoRetryPolicy))
+	server.InjectFault(imagekittest.Fault{StatusCode: http.StatusTooManyRequests, Times: 2})
+	for i := 0; i < 2; i++ {
+		if _, err := imgKit.GetFiles(&imagekit.FilesFetchParams{}); !imagekit.IsRateLimited(err) {
+			t.Errorf("request %d = %v, want a rate limit error", i, err)
+		}
+	}
+	if _, err := imgKit.GetFiles(&imagekit.FilesFetchParams{}); err != nil {
+		t.Errorf("request after the faults = %v", err)
+	}
+}
+
+func TestServerFaultPathPrefix(t *testing.T) {
+	server := imagekittest.NewServer()
+	defer server.Close()
+	imgKit := server.ImageKit(imagekit.WithRetryPolicy(imagekit.NoRetryPolicy))
+	server.InjectFault(imagekittest.Fault{
+		Method:     http.MethodPost,
+		PathPrefix: imagekittest.API_PATH + "/files/purge",
+		StatusCode: http.StatusInternalServerError,
+	})
+	if _, err := imgKit.PurgeCache(server.URL + imagekittest.URL_ENDPOINT_PATH + "/a.jpg"); !imagekit.IsServerError(err) {
+		t.Errorf("PurgeCache = %v, want a server error", err)
+	}
+	if _, err := imgKit.GetFiles(&imagekit.FilesFetchParams{}); err != nil {
+		t.Errorf("GetFiles = %v, want no error outside the path prefix", err)
+	}
+	server.ClearFaults()
+	if _, err := imgKit.PurgeCache(server.URL + imagekittest.URL_ENDPOINT_PATH + "/a.jpg"); err != nil {
+		t.Errorf("PurgeCache after clearing the faults = %v", err)
+	}
+	requests := server.Requests()
+	if len(requests) != 3 || requests[0].Method != http.MethodPost || requests[1].Path != imagekittest.API_PATH+"/files" {
+		t.Errorf("requests = %+v", requests)
+	}
+}
+
+func TestServerBulkJobPolling(t *testing.T) {
+	server := imagekittest.NewServer()
+	defer server.Close()
+	server.JobPendingPolls = 2
+	imgKit := server.ImageKit()
+	server.AddFile("a.txt", "/source", []byte("a"))
+	server.AddFile("b.txt", "/destination", []byte("b"))
+	jobId, err := imgKit.CopyFolder("/source", "/destination")
+	if err != nil {
+		t.Fatal(err)
+	}
+	statuses := []string{}
+	for i := 0; i < 3; i++ {
+		job, err := imgKit.GetBulkJobStatus(jobId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		statuses = append(statuses, string(job.Status))
+	}
+	want := []string{imagekit.JOB_STATUS_PENDING, imagekit.JOB_STATUS_PENDING, imagekit.JOB_STATUS_COMPLETED}
+	if strings.Join(statuses, ",") != strings.Join(want, ",") {
+		t.Errorf("statuses = %v, want %v", statuses, want)
+	}
+	copied := false
+	for _, file := range server.Files() {
+		copied = copied || string(*file.FilePath) == "/destination/source/a.txt"
+	}
+	if !copied {
+		t.Error("the folder was not copied")
+	}
+	if _, err = imgKit.GetBulkJobStatus("missing"); !imagekit.IsNotFound(err) {
+		t.Errorf("GetBulkJobStatus of a missing job = %v, want a not found error", err)
+	}
+}
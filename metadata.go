@@ -0,0 +1,180 @@
+package imagekit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Represents metadata about an image or video.
+type Metadata struct {
+	Height          Int32    `json:"height" binding:"-"`
+	Width           Int32    `json:"width" binding:"-"`
+	Size            Int32    `json:"size" binding:"-"`
+	Format          *String  `json:"format" binding:"-"`
+	HasColorProfile *Bool    `json:"hasColorProfile" binding:"-"`
+	Quality         Int32    `json:"quality" binding:"-"`
+	Density         Int32    `json:"density" binding:"-"`
+	HasTransparency *Bool    `json:"hasTransparency" binding:"-"`
+	PHash           *String  `json:"pHash" binding:"-"`
+	Exif            *Exif    `json:"exif" binding:"-"`
+	Duration        *Float64 `json:"duration" binding:"-"`
+	VideoCodec      *String  `json:"videoCodec" binding:"-"`
+	AudioCodec      *String  `json:"audioCodec" binding:"-"`
+	BitRate         *Float64 `json:"bitRate" binding:"-"`
+}
+
+// Represents the EXIF sections of an image's metadata.
+type Exif struct {
+	Image            *ExifImage             `json:"image" binding:"-"`
+	Thumbnail        *ExifThumbnail         `json:"thumbnail" binding:"-"`
+	Exif             *ExifDetails           `json:"exif" binding:"-"`
+	Gps              *ExifGps               `json:"gps" binding:"-"`
+	Interoperability *ExifInteroperability  `json:"interoperability" binding:"-"`
+	Makernote        map[string]interface{} `json:"makernote" binding:"-"`
+}
+
+// Represents the image section of EXIF data.
+type ExifImage struct {
+	Make             *String  `json:"Make" binding:"-"`
+	Model            *String  `json:"Model" binding:"-"`
+	Orientation      *Float64 `json:"Orientation" binding:"-"`
+	XResolution      *Float64 `json:"XResolution" binding:"-"`
+	YResolution      *Float64 `json:"YResolution" binding:"-"`
+	ResolutionUnit   *Float64 `json:"ResolutionUnit" binding:"-"`
+	Software         *String  `json:"Software" binding:"-"`
+	ModifyDate       *String  `json:"ModifyDate" binding:"-"`
+	YCbCrPositioning *Float64 `json:"YCbCrPositioning" binding:"-"`
+	ExifOffset       *Float64 `json:"ExifOffset" binding:"-"`
+	GPSInfo          *Float64 `json:"GPSInfo" binding:"-"`
+}
+
+// Represents the thumbnail section of EXIF data.
+type ExifThumbnail struct {
+	Compression     *Float64 `json:"Compression" binding:"-"`
+	XResolution     *Float64 `json:"XResolution" binding:"-"`
+	YResolution     *Float64 `json:"YResolution" binding:"-"`
+	ResolutionUnit  *Float64 `json:"ResolutionUnit" binding:"-"`
+	ThumbnailOffset *Float64 `json:"ThumbnailOffset" binding:"-"`
+	ThumbnailLength *Float64 `json:"ThumbnailLength" binding:"-"`
+}
+
+// Represents the exif section of EXIF data.
+type ExifDetails struct {
+	ExposureTime             *Float64 `json:"ExposureTime" binding:"-"`
+	FNumber                  *Float64 `json:"FNumber" binding:"-"`
+	ExposureProgram          *Float64 `json:"ExposureProgram" binding:"-"`
+	ISO                      *Float64 `json:"ISO" binding:"-"`
+	ExifVersion              *String  `json:"ExifVersion" binding:"-"`
+	DateTimeOriginal         *String  `json:"DateTimeOriginal" binding:"-"`
+	CreateDate               *String  `json:"CreateDate" binding:"-"`
+	ShutterSpeedValue        *Float64 `json:"ShutterSpeedValue" binding:"-"`
+	ApertureValue            *Float64 `json:"ApertureValue" binding:"-"`
+	ExposureCompensation     *Float64 `json:"ExposureCompensation" binding:"-"`
+	MeteringMode             *Float64 `json:"MeteringMode" binding:"-"`
+	Flash                    *Float64 `json:"Flash" binding:"-"`
+	FocalLength              *Float64 `json:"FocalLength" binding:"-"`
+	SubSecTime               *String  `json:"SubSecTime" binding:"-"`
+	SubSecTimeOriginal       *String  `json:"SubSecTimeOriginal" binding:"-"`
+	SubSecTimeDigitized      *String  `json:"SubSecTimeDigitized" binding:"-"`
+	FlashpixVersion          *String  `json:"FlashpixVersion" binding:"-"`
+	ColorSpace               *Float64 `json:"ColorSpace" binding:"-"`
+	ExifImageWidth           *Float64 `json:"ExifImageWidth" binding:"-"`
+	ExifImageHeight          *Float64 `json:"ExifImageHeight" binding:"-"`
+	InteropOffset            *Float64 `json:"InteropOffset" binding:"-"`
+	FocalPlaneXResolution    *Float64 `json:"FocalPlaneXResolution" binding:"-"`
+	FocalPlaneYResolution    *Float64 `json:"FocalPlaneYResolution" binding:"-"`
+	FocalPlaneResolutionUnit *Float64 `json:"FocalPlaneResolutionUnit" binding:"-"`
+	CustomRendered           *Float64 `json:"CustomRendered" binding:"-"`
+	ExposureMode             *Float64 `json:"ExposureMode" binding:"-"`
+	WhiteBalance             *Float64 `json:"WhiteBalance" binding:"-"`
+	SceneCaptureType         *Float64 `json:"SceneCaptureType" binding:"-"`
+}
+
+// Represents the GPS section of EXIF data.
+type ExifGps struct {
+	GPSVersionID    []Int32   `json:"GPSVersionID" binding:"-"`
+	GPSLatitudeRef  *String   `json:"GPSLatitudeRef" binding:"-"`
+	GPSLatitude     []Float64 `json:"GPSLatitude" binding:"-"`
+	GPSLongitudeRef *String   `json:"GPSLongitudeRef" binding:"-"`
+	GPSLongitude    []Float64 `json:"GPSLongitude" binding:"-"`
+	GPSAltitudeRef  *Float64  `json:"GPSAltitudeRef" binding:"-"`
+	GPSAltitude     *Float64  `json:"GPSAltitude" binding:"-"`
+	GPSTimeStamp    []Float64 `json:"GPSTimeStamp" binding:"-"`
+	GPSDateStamp    *String   `json:"GPSDateStamp" binding:"-"`
+}
+
+// Represents the interoperability section of EXIF data.
+type ExifInteroperability struct {
+	InteropIndex   *String `json:"InteropIndex" binding:"-"`
+	InteropVersion *String `json:"InteropVersion" binding:"-"`
+}
+
+// Get the metadata of a file in the media library.
+func (imgKit *ImageKit) GetFileMetadata(fileId string) (metadata *Metadata, err error) {
+	return imgKit.GetFileMetadataWithContext(context.Background(), fileId)
+}
+
+// Get the metadata of a file in the media library using the given context.
+func (imgKit *ImageKit) GetFileMetadataWithContext(
+	ctx context.Context,
+	fileId string) (metadata *Metadata, err error) {
+	return imgKit.getMetadata(
+		ctx,
+		fmt.Sprintf("%s/files/%s/metadata", imgKit.getBaseUrl(), fileId),
+	)
+}
+
+// Get the metadata of a file accessible through a URL.
+func (imgKit *ImageKit) GetRemoteFileMetadata(fileUrl string) (metadata *Metadata, err error) {
+	return imgKit.GetRemoteFileMetadataWithContext(context.Background(), fileUrl)
+}
+
+// Get the metadata of a file accessible through a URL using the given
+// context.
+func (imgKit *ImageKit) GetRemoteFileMetadataWithContext(
+	ctx context.Context,
+	fileUrl string) (metadata *Metadata, err error) {
+	if len(strings.TrimSpace(fileUrl)) == 0 {
+		return nil, errors.New("fileUrl must not be empty")
+	}
+	return imgKit.getMetadata(
+		ctx,
+		fmt.Sprintf(
+			"%s/metadata?url=%s",
+			imgKit.getBaseUrl(),
+			url.QueryEscape(fileUrl),
+		),
+	)
+}
+
+// Fetches metadata from an endpoint.
+func (imgKit *ImageKit) getMetadata(
+	ctx context.Context,
+	endpoint string) (metadata *Metadata, err error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		endpoint,
+		bytes.NewBufferString(""),
+	)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resBodyStr, err := imgKit.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	metadata = &Metadata{}
+	err = json.Unmarshal([]byte(resBodyStr), metadata)
+	if err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
@@ -0,0 +1,199 @@
+package imagekit
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"math"
+	"math/bits"
+	"sort"
+)
+
+const (
+	PHASH_IMAGE_SIZE = 32
+	PHASH_HASH_SIZE  = 8
+)
+
+// The DCT-II cosine coefficients of the sample size of a perceptual hash,
+// for the frequencies up to and including the last one in the hash.
+var phashCosines = func() (cosines [PHASH_HASH_SIZE + 1][PHASH_IMAGE_SIZE]float64) {
+	for u := 0; u <= PHASH_HASH_SIZE; u++ {
+		for x := 0; x < PHASH_IMAGE_SIZE; x++ {
+			cosines[u][x] = math.Cos(
+				float64((2*x+1)*u) * math.Pi / float64(2*PHASH_IMAGE_SIZE),
+			)
+		}
+	}
+	return cosines
+}()
+
+// Computes the DCT-based perceptual hash of an image as a 16-character
+// hexadecimal string, so local images can be compared with each other with
+// PHashDistance. The hash is not the pHash computed by ImageKit.io and
+// must not be compared with the hashes in remote file metadata.
+func PHash(img image.Image) (hash string, err error) {
+	if img == nil {
+		return "", errors.New("image must not be nil")
+	}
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return "", errors.New("image must not be empty")
+	}
+	// Downscale the luminance of the image by averaging the pixels that
+	// fall in each sample.
+	var pixels [PHASH_IMAGE_SIZE][PHASH_IMAGE_SIZE]float64
+	for sy := 0; sy < PHASH_IMAGE_SIZE; sy++ {
+		y0 := sy * height / PHASH_IMAGE_SIZE
+		y1 := (sy + 1) * height / PHASH_IMAGE_SIZE
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for sx := 0; sx < PHASH_IMAGE_SIZE; sx++ {
+			x0 := sx * width / PHASH_IMAGE_SIZE
+			x1 := (sx + 1) * width / PHASH_IMAGE_SIZE
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+			sum := 0.0
+			for y := y0; y < y1; y++ {
+				for x := x0; x < x1; x++ {
+					r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+					sum += 0.299*float64(r>>8) + 0.587*float64(g>>8) + 0.114*float64(b>>8)
+				}
+			}
+			pixels[sy][sx] = sum / float64((y1-y0)*(x1-x0))
+		}
+	}
+	// Keep the 8x8 lowest frequencies of the two-dimensional DCT of the
+	// samples starting from the second row and column, which leaves out the
+	// DC term that only reflects the average brightness.
+	var rows [PHASH_IMAGE_SIZE][PHASH_HASH_SIZE]float64
+	for y := 0; y < PHASH_IMAGE_SIZE; y++ {
+		for u := 0; u < PHASH_HASH_SIZE; u++ {
+			for x := 0; x < PHASH_IMAGE_SIZE; x++ {
+				rows[y][u] += pixels[y][x] * phashCosines[u+1][x]
+			}
+		}
+	}
+	coefficients := make([]float64, PHASH_HASH_SIZE*PHASH_HASH_SIZE)
+	for v := 0; v < PHASH_HASH_SIZE; v++ {
+		for u := 0; u < PHASH_HASH_SIZE; u++ {
+			for y := 0; y < PHASH_IMAGE_SIZE; y++ {
+				coefficients[v*PHASH_HASH_SIZE+u] += rows[y][u] * phashCosines[v+1][y]
+			}
+		}
+	}
+	sorted := append([]float64{}, coefficients...)
+	sort.Float64s(sorted)
+	median := (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+	var hashBits uint64
+	for _, coefficient := range coefficients {
+		hashBits <<= 1
+		if coefficient > median {
+			hashBits |= 1
+		}
+	}
+	return fmt.Sprintf("%016x", hashBits), nil
+}
+
+// Decodes a JPEG, PNG or GIF image and computes its perceptual hash.
+func PHashFromReader(reader io.Reader) (hash string, err error) {
+	if reader == nil {
+		return "", errors.New("reader must not be nil")
+	}
+	img, _, err := image.Decode(reader)
+	if err != nil {
+		return "", err
+	}
+	return PHash(img)
+}
+
+// Decodes a hexadecimal hash into its 4-bit digits.
+func decodeHexHash(hash string) (digits []byte, err error) {
+	if len(hash) == 0 {
+		return nil, errors.New("hash must not be empty")
+	}
+	digits = make([]byte, len(hash))
+	for i := 0; i < len(hash); i++ {
+		c := hash[i]
+		switch {
+		case c >= '0' && c <= '9':
+			digits[i] = c - '0'
+		case c >= 'a' && c <= 'f':
+			digits[i] = c - 'a' + 10
+		case c >= 'A' && c <= 'F':
+			digits[i] = c - 'A' + 10
+		default:
+			return nil, fmt.Errorf("hash %q is not hexadecimal", hash)
+		}
+	}
+	return digits, nil
+}
+
+// Computes the Hamming distance between two hexadecimal hashes of equal
+// length, which may have any number of bits.
+func PHashDistance(hash1, hash2 string) (distance int, err error) {
+	if len(hash1) != len(hash2) {
+		return 0, errors.New("the hashes must be of equal length")
+	}
+	digits1, err := decodeHexHash(hash1)
+	if err != nil {
+		return 0, err
+	}
+	digits2, err := decodeHexHash(hash2)
+	if err != nil {
+		return 0, err
+	}
+	for i := range digits1 {
+		distance += bits.OnesCount8(digits1[i] ^ digits2[i])
+	}
+	return distance, nil
+}
+
+// Computes the similarity of two hexadecimal hashes of equal length as the
+// percentage of their bits that are equal.
+func PHashSimilarity(hash1, hash2 string) (similarity float64, err error) {
+	distance, err := PHashDistance(hash1, hash2)
+	if err != nil {
+		return 0, err
+	}
+	bitCount := 4 * len(hash1)
+	return 100 * float64(bitCount-distance) / float64(bitCount), nil
+}
+
+// Computes the Hamming distances between a hash and each of the hashes.
+func PHashDistances(hash string, hashes []string) (distances []int, err error) {
+	distances = make([]int, len(hashes))
+	for i, other := range hashes {
+		distances[i], err = PHashDistance(hash, other)
+		if err != nil {
+			return nil, fmt.Errorf("hash %d: %w", i, err)
+		}
+	}
+	return distances, nil
+}
+
+// Computes the Hamming distances between all pairs of the hashes, where
+// distances[i][j] is the distance between hashes[i] and hashes[j].
+func PHashPairwiseDistances(hashes []string) (distances [][]int, err error) {
+	distances = make([][]int, len(hashes))
+	for i := range hashes {
+		distances[i] = make([]int, len(hashes))
+	}
+	for i := range hashes {
+		for j := i + 1; j < len(hashes); j++ {
+			distance, err := PHashDistance(hashes[i], hashes[j])
+			if err != nil {
+				return nil, fmt.Errorf("hashes %d and %d: %w", i, j, err)
+			}
+			distances[i][j] = distance
+			distances[j][i] = distance
+		}
+	}
+	return distances, nil
+}
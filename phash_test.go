@@ -0,0 +1,21 @@
+package imagekit
+
+import "testing"
+
+func TestDeprecatedPHashDistance(t *testing.T) {
+	imgKit := New("public", "private", "https://ik.imagekit.io/demo")
+	distance, err := imgKit.PHashDistance("f06830ca9f1e3e90", "f06830ca9f1e3e91")
+	if err != nil || distance != 1 {
+		t.Errorf("PHashDistance = %d, %v, want 1", distance, err)
+	}
+	invalid := [][2]string{
+		{"f06830ca9f1e3e90", "f06830ca9f1e3e9"},
+		{"f06830ca9f1e3e90", "g06830ca9f1e3e90"},
+		{"f06830ca9f1e3e90f", "f06830ca9f1e3e90f"},
+	}
+	for _, hashes := range invalid {
+		if _, err := imgKit.PHashDistance(hashes[0], hashes[1]); err == nil {
+			t.Errorf("PHashDistance(%q, %q) returned no error", hashes[0], hashes[1])
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package imagekit
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Represents a policy for retrying failed requests.
+type RetryPolicy struct {
+	// The maximum number of times a request is sent, including the first.
+	MaxAttempts int
+	// The wait time before the first retry.
+	InitialBackoff time.Duration
+	// The upper bound of the wait time between retries, including wait times
+	// requested by the server.
+	MaxBackoff time.Duration
+	// The factor the wait time grows by after each retry, where 1 gives
+	// a constant backoff.
+	Multiplier float64
+	// The fraction of the wait time, between 0 and 1, that is randomized.
+	Jitter float64
+	// The response status codes that cause a request to be retried.
+	// Requests with non-idempotent methods are only retried on 429 Too Many
+	// Requests unless RetryNonIdempotentRequests is set.
+	RetryableStatusCodes []int
+	// Whether requests with idempotent methods failing with a network error
+	// are retried.
+	RetryNetworkErrors bool
+	// Whether requests with non-idempotent methods such as POST are also
+	// retried on network errors and server errors. Such a request may have
+	// been processed by the server, so retrying it can repeat its effect,
+	// such as uploading a file twice.
+	RetryNonIdempotentRequests bool
+	// Computes the wait time before a retry, overriding the exponential
+	// backoff when set.
+	Backoff func(attempt int) time.Duration
+}
+
+// The retry policy used by ImageKit instances without a policy of their own.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    5,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     30 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+	RetryableStatusCodes: []int{
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	},
+	RetryNetworkErrors: true,
+}
+
+// A retry policy that sends each request only once.
+var NoRetryPolicy = RetryPolicy{MaxAttempts: 1}
+
+// Sets the policy for retrying failed requests.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(imgKit *ImageKit) {
+		imgKit.RetryPolicy = &policy
+	}
+}
+
+// Gets the policy for retrying failed requests.
+func (imgKit *ImageKit) getRetryPolicy() *RetryPolicy {
+	if imgKit.RetryPolicy != nil {
+		return imgKit.RetryPolicy
+	}
+	return &DefaultRetryPolicy
+}
+
+// Checks if a request has an idempotent method, so sending it again has
+// no further effect.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// Checks if a request can be retried after a response with the given
+// status code. Requests with non-idempotent methods are only retried on
+// 429 Too Many Requests, which is returned before they are processed,
+// unless RetryNonIdempotentRequests is set.
+func (policy *RetryPolicy) isRetryableStatus(req *http.Request, statusCode int) bool {
+	retryable := false
+	for _, code := range policy.RetryableStatusCodes {
+		retryable = retryable || code == statusCode
+	}
+	return retryable && (statusCode == http.StatusTooManyRequests ||
+		isIdempotent(req) || policy.RetryNonIdempotentRequests)
+}
+
+// Checks if a request can be retried after a network error.
+func (policy *RetryPolicy) isRetryableNetworkError(req *http.Request) bool {
+	return policy.RetryNetworkErrors && (isIdempotent(req) || policy.RetryNonIdempotentRequests)
+}
+
+// Computes the wait time before the retry following the given attempt.
+func (policy *RetryPolicy) backoff(attempt int) time.Duration {
+	if policy.Backoff != nil {
+		return policy.Backoff(attempt)
+	}
+	multiplier := policy.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	wait := float64(policy.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if policy.MaxBackoff > 0 && wait > float64(policy.MaxBackoff) {
+		wait = float64(policy.MaxBackoff)
+	}
+	if policy.Jitter > 0 {
+		jitter := math.Min(policy.Jitter, 1)
+		wait += wait * jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(wait)
+}
+
+// Computes the wait time before retrying a failed response, preferring the
+// wait time requested by the server up to MaxBackoff.
+func (policy *RetryPolicy) responseBackoff(
+	attempt int,
+	res *http.Response) time.Duration {
+	wait := policy.serverBackoff(attempt, res)
+	if policy.MaxBackoff > 0 && wait > policy.MaxBackoff {
+		return policy.MaxBackoff
+	}
+	return wait
+}
+
+// Gets the wait time requested by the server in a failed response, or the
+// computed wait time if there is none.
+func (policy *RetryPolicy) serverBackoff(
+	attempt int,
+	res *http.Response) time.Duration {
+	if retryAfter := res.Header.Get("Retry-After"); len(retryAfter) > 0 {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			if wait := time.Until(date); wait > 0 {
+				return wait
+			}
+			return 0
+		}
+	}
+	if reset := res.Header.Get("X-RateLimit-Reset"); len(reset) > 0 {
+		if millis, err := strconv.ParseInt(reset, 10, 64); err == nil && millis >= 0 {
+			return time.Duration(millis) * time.Millisecond
+		}
+	}
+	return policy.backoff(attempt)
+}
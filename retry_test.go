@@ -0,0 +1,67 @@
+package imagekit
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Gets a retry policy like the default one that does not wait between
+// attempts.
+func newTestRetryPolicy() RetryPolicy {
+	policy := DefaultRetryPolicy
+	policy.Backoff = func(attempt int) time.Duration { return 0 }
+	return policy
+}
+
+func TestNonIdempotentRequestIsNotRetriedOnServerError(t *testing.T) {
+	imgKit, paths := newRecordedImageKit(t, http.StatusBadGateway, `{"message": "Bad Gateway"}`)
+	policy := newTestRetryPolicy()
+	imgKit.RetryPolicy = &policy
+	_, err := imgKit.AddTags([]string{"598821f949c0a938d57563bd"}, []string{"summer"})
+	if !IsServerError(err) {
+		t.Errorf("err = %v, want a server error", err)
+	}
+	if len(*paths) != 1 {
+		t.Errorf("POST was sent %d times, want 1", len(*paths))
+	}
+}
+
+func TestNonIdempotentRequestIsRetriedWhenAllowed(t *testing.T) {
+	imgKit, paths := newRecordedImageKit(t, http.StatusBadGateway, `{"message": "Bad Gateway"}`)
+	policy := newTestRetryPolicy()
+	policy.RetryNonIdempotentRequests = true
+	imgKit.RetryPolicy = &policy
+	imgKit.AddTags([]string{"598821f949c0a938d57563bd"}, []string{"summer"})
+	if len(*paths) != policy.MaxAttempts {
+		t.Errorf("POST was sent %d times, want %d", len(*paths), policy.MaxAttempts)
+	}
+}
+
+func TestRetryableStatuses(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		attempts   int
+	}{
+		{http.StatusTooManyRequests, 5},
+		{http.StatusBadGateway, 1},
+		{http.StatusGatewayTimeout, 1},
+		{http.StatusBadRequest, 1},
+	}
+	for _, test := range tests {
+		imgKit, paths := newRecordedImageKit(t, test.statusCode, `{"message": "error"}`)
+		policy := newTestRetryPolicy()
+		imgKit.RetryPolicy = &policy
+		imgKit.PurgeCache("https://ik.imagekit.io/demo/default-image.jpg")
+		if len(*paths) != test.attempts {
+			t.Errorf("POST failing with %d was sent %d times, want %d", test.statusCode, len(*paths), test.attempts)
+		}
+	}
+	imgKit, paths := newRecordedImageKit(t, http.StatusBadGateway, `{"message": "Bad Gateway"}`)
+	policy := newTestRetryPolicy()
+	imgKit.RetryPolicy = &policy
+	imgKit.GetPurgeCacheStatus("598821f949c0a938d57563bd")
+	if len(*paths) != policy.MaxAttempts {
+		t.Errorf("GET failing with 502 was sent %d times, want %d", len(*paths), policy.MaxAttempts)
+	}
+}
@@ -0,0 +1,339 @@
+package imagekit
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Represents a field that files can be searched by.
+type SearchField string
+
+const (
+	SEARCH_FIELD_NAME         SearchField = "name"
+	SEARCH_FIELD_TAGS         SearchField = "tags"
+	SEARCH_FIELD_AI_TAGS      SearchField = "aiTags"
+	SEARCH_FIELD_TYPE         SearchField = "type"
+	SEARCH_FIELD_FORMAT       SearchField = "format"
+	SEARCH_FIELD_PRIVATE      SearchField = "private"
+	SEARCH_FIELD_PUBLISHED    SearchField = "published"
+	SEARCH_FIELD_TRANSPARENCY SearchField = "transparency"
+	SEARCH_FIELD_SIZE         SearchField = "size"
+	SEARCH_FIELD_WIDTH        SearchField = "width"
+	SEARCH_FIELD_HEIGHT       SearchField = "height"
+	SEARCH_FIELD_CREATED_AT   SearchField = "createdAt"
+	SEARCH_FIELD_UPDATED_AT   SearchField = "updatedAt"
+)
+
+const (
+	SEARCH_OPERATOR_EQUALS                = "="
+	SEARCH_OPERATOR_CONTAINS              = ":"
+	SEARCH_OPERATOR_IN                    = "IN"
+	SEARCH_OPERATOR_GREATER_THAN          = ">"
+	SEARCH_OPERATOR_GREATER_THAN_OR_EQUAL = ">="
+	SEARCH_OPERATOR_LESS_THAN             = "<"
+	SEARCH_OPERATOR_LESS_THAN_OR_EQUAL    = "<="
+	SEARCH_OPERATOR_HAS                   = "HAS"
+	SEARCH_CONJUNCTION_AND                = "AND"
+	SEARCH_CONJUNCTION_OR                 = "OR"
+	CUSTOM_METADATA_FIELD_PREFIX          = "customMetadata."
+	EMBEDDED_METADATA_FIELD_PREFIX        = "embeddedMetadata."
+)
+
+var searchComparisonOperators = []string{
+	SEARCH_OPERATOR_EQUALS,
+	SEARCH_OPERATOR_IN,
+	SEARCH_OPERATOR_GREATER_THAN,
+	SEARCH_OPERATOR_GREATER_THAN_OR_EQUAL,
+	SEARCH_OPERATOR_LESS_THAN,
+	SEARCH_OPERATOR_LESS_THAN_OR_EQUAL,
+}
+
+// The operators supported by each search field.
+var VALID_SEARCH_OPERATORS = map[SearchField][]string{
+	SEARCH_FIELD_NAME: {
+		SEARCH_OPERATOR_EQUALS,
+		SEARCH_OPERATOR_CONTAINS,
+		SEARCH_OPERATOR_IN,
+	},
+	SEARCH_FIELD_TAGS: {
+		SEARCH_OPERATOR_EQUALS,
+		SEARCH_OPERATOR_IN,
+		SEARCH_OPERATOR_HAS,
+	},
+	SEARCH_FIELD_AI_TAGS: {
+		SEARCH_OPERATOR_EQUALS,
+		SEARCH_OPERATOR_IN,
+		SEARCH_OPERATOR_HAS,
+	},
+	SEARCH_FIELD_TYPE:         {SEARCH_OPERATOR_EQUALS, SEARCH_OPERATOR_IN},
+	SEARCH_FIELD_FORMAT:       {SEARCH_OPERATOR_EQUALS, SEARCH_OPERATOR_IN},
+	SEARCH_FIELD_PRIVATE:      {SEARCH_OPERATOR_EQUALS},
+	SEARCH_FIELD_PUBLISHED:    {SEARCH_OPERATOR_EQUALS},
+	SEARCH_FIELD_TRANSPARENCY: {SEARCH_OPERATOR_EQUALS},
+	SEARCH_FIELD_SIZE:         searchComparisonOperators,
+	SEARCH_FIELD_WIDTH:        searchComparisonOperators,
+	SEARCH_FIELD_HEIGHT:       searchComparisonOperators,
+	SEARCH_FIELD_CREATED_AT:   searchComparisonOperators,
+	SEARCH_FIELD_UPDATED_AT:   searchComparisonOperators,
+}
+
+// The operators supported by custom and embedded metadata fields.
+var VALID_METADATA_SEARCH_OPERATORS = []string{
+	SEARCH_OPERATOR_EQUALS,
+	SEARCH_OPERATOR_CONTAINS,
+	SEARCH_OPERATOR_IN,
+	SEARCH_OPERATOR_GREATER_THAN,
+	SEARCH_OPERATOR_GREATER_THAN_OR_EQUAL,
+	SEARCH_OPERATOR_LESS_THAN,
+	SEARCH_OPERATOR_LESS_THAN_OR_EQUAL,
+	SEARCH_OPERATOR_HAS,
+}
+
+// The operators that can be negated with NOT.
+var negatableSearchOperators = []string{
+	SEARCH_OPERATOR_EQUALS,
+	SEARCH_OPERATOR_CONTAINS,
+	SEARCH_OPERATOR_IN,
+}
+
+// The complements of comparison operators, used to negate them.
+var searchOperatorComplements = map[string]string{
+	SEARCH_OPERATOR_GREATER_THAN:          SEARCH_OPERATOR_LESS_THAN_OR_EQUAL,
+	SEARCH_OPERATOR_GREATER_THAN_OR_EQUAL: SEARCH_OPERATOR_LESS_THAN,
+	SEARCH_OPERATOR_LESS_THAN:             SEARCH_OPERATOR_GREATER_THAN_OR_EQUAL,
+	SEARCH_OPERATOR_LESS_THAN_OR_EQUAL:    SEARCH_OPERATOR_GREATER_THAN,
+}
+
+// Represents a search query expression, which is either a condition on a
+// field or a conjunction of other expressions.
+type SearchQuery struct {
+	field       SearchField
+	operator    string
+	values      []interface{}
+	negated     bool
+	conjunction string
+	queries     []SearchQuery
+}
+
+// Creates a field of the custom metadata of files.
+func CustomMetadataField(name string) SearchField {
+	return SearchField(CUSTOM_METADATA_FIELD_PREFIX + name)
+}
+
+// Creates a field of the embedded metadata of files.
+func EmbeddedMetadataField(name string) SearchField {
+	return SearchField(EMBEDDED_METADATA_FIELD_PREFIX + name)
+}
+
+// Creates a condition comparing the field with a value.
+func (field SearchField) condition(
+	operator string,
+	values ...interface{}) SearchQuery {
+	return SearchQuery{field: field, operator: operator, values: values}
+}
+
+// Creates a condition matching a field equal to the value.
+func (field SearchField) Equals(value interface{}) SearchQuery {
+	return field.condition(SEARCH_OPERATOR_EQUALS, value)
+}
+
+// Creates a condition matching a field containing the value.
+func (field SearchField) Contains(value interface{}) SearchQuery {
+	return field.condition(SEARCH_OPERATOR_CONTAINS, value)
+}
+
+// Creates a condition matching a field equal to any of the values.
+func (field SearchField) In(values ...interface{}) SearchQuery {
+	return field.condition(SEARCH_OPERATOR_IN, values...)
+}
+
+// Creates a condition matching a field greater than the value.
+func (field SearchField) GreaterThan(value interface{}) SearchQuery {
+	return field.condition(SEARCH_OPERATOR_GREATER_THAN, value)
+}
+
+// Creates a condition matching a field greater than or equal to the value.
+func (field SearchField) GreaterThanOrEqual(value interface{}) SearchQuery {
+	return field.condition(SEARCH_OPERATOR_GREATER_THAN_OR_EQUAL, value)
+}
+
+// Creates a condition matching a field less than the value.
+func (field SearchField) LessThan(value interface{}) SearchQuery {
+	return field.condition(SEARCH_OPERATOR_LESS_THAN, value)
+}
+
+// Creates a condition matching a field less than or equal to the value.
+func (field SearchField) LessThanOrEqual(value interface{}) SearchQuery {
+	return field.condition(SEARCH_OPERATOR_LESS_THAN_OR_EQUAL, value)
+}
+
+// Creates a condition matching a list field that has the value.
+func (field SearchField) Has(value interface{}) SearchQuery {
+	return field.condition(SEARCH_OPERATOR_HAS, value)
+}
+
+// Creates an expression matching files that satisfy all the queries.
+func And(queries ...SearchQuery) SearchQuery {
+	return SearchQuery{conjunction: SEARCH_CONJUNCTION_AND, queries: queries}
+}
+
+// Creates an expression matching files that satisfy any of the queries.
+func Or(queries ...SearchQuery) SearchQuery {
+	return SearchQuery{conjunction: SEARCH_CONJUNCTION_OR, queries: queries}
+}
+
+// Creates an expression matching files that do not satisfy the query.
+func Not(query SearchQuery) SearchQuery {
+	if len(query.conjunction) == 0 {
+		query.negated = !query.negated
+		return query
+	}
+	negatedQuery := SearchQuery{conjunction: SEARCH_CONJUNCTION_AND}
+	if query.conjunction == SEARCH_CONJUNCTION_AND {
+		negatedQuery.conjunction = SEARCH_CONJUNCTION_OR
+	}
+	for _, subQuery := range query.queries {
+		negatedQuery.queries = append(negatedQuery.queries, Not(subQuery))
+	}
+	return negatedQuery
+}
+
+// Gets the operators supported by a field.
+func (field SearchField) operators() (operators []string, err error) {
+	if strings.HasPrefix(string(field), CUSTOM_METADATA_FIELD_PREFIX) ||
+		strings.HasPrefix(string(field), EMBEDDED_METADATA_FIELD_PREFIX) {
+		if strings.HasSuffix(string(field), ".") {
+			return nil, errors.New("metadata field name must not be empty")
+		}
+		return VALID_METADATA_SEARCH_OPERATORS, nil
+	}
+	operators, ok := VALID_SEARCH_OPERATORS[field]
+	if !ok {
+		return nil, fmt.Errorf("invalid search field %q", field)
+	}
+	return operators, nil
+}
+
+// Formats a value of a search query.
+func formatSearchValue(
+	field SearchField,
+	value interface{}) (str string, err error) {
+	switch field {
+	case SEARCH_FIELD_PRIVATE, SEARCH_FIELD_PUBLISHED, SEARCH_FIELD_TRANSPARENCY:
+		if reflect.ValueOf(value).Kind() != reflect.Bool {
+			return "", fmt.Errorf("%s must be compared with a boolean", field)
+		}
+	case SEARCH_FIELD_WIDTH, SEARCH_FIELD_HEIGHT:
+		switch reflect.ValueOf(value).Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+		default:
+			return "", fmt.Errorf("%s must be compared with a number", field)
+		}
+	case SEARCH_FIELD_CREATED_AT, SEARCH_FIELD_UPDATED_AT:
+		switch value.(type) {
+		case time.Time, string, String:
+		default:
+			return "", fmt.Errorf("%s must be compared with a time or string", field)
+		}
+	}
+	if t, ok := value.(time.Time); ok {
+		return strconv.Quote(t.UTC().Format(time.RFC3339)), nil
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String:
+		return strconv.Quote(v.String()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
+	}
+	return "", fmt.Errorf("unsupported value type %T for %s", value, field)
+}
+
+// Converts a condition to its string representation.
+func (query SearchQuery) conditionString() (str string, err error) {
+	operators, err := query.field.operators()
+	if err != nil {
+		return "", err
+	}
+	if !String(query.operator).StringInArray(operators) {
+		return "", fmt.Errorf(
+			"operator %s is not supported by %s",
+			query.operator,
+			query.field,
+		)
+	}
+	operator := query.operator
+	if query.negated {
+		if complement, ok := searchOperatorComplements[operator]; ok {
+			operator = complement
+		} else if String(operator).StringInArray(negatableSearchOperators) {
+			operator = "NOT " + operator
+		} else {
+			return "", fmt.Errorf("operator %s cannot be negated", operator)
+		}
+	}
+	if len(query.values) == 0 {
+		return "", fmt.Errorf("%s must be compared with a value", query.field)
+	}
+	values := make([]string, len(query.values))
+	for i, value := range query.values {
+		values[i], err = formatSearchValue(query.field, value)
+		if err != nil {
+			return "", err
+		}
+	}
+	valueStr := values[0]
+	if query.operator == SEARCH_OPERATOR_IN {
+		valueStr = fmt.Sprintf("[%s]", strings.Join(values, ", "))
+	} else if len(values) > 1 {
+		return "", fmt.Errorf("operator %s takes a single value", query.operator)
+	}
+	return fmt.Sprintf("%s %s %s", query.field, operator, valueStr), nil
+}
+
+// Validates the query and converts it to its string representation.
+func (query SearchQuery) ToString() (str String, err error) {
+	if len(query.conjunction) == 0 {
+		conditionStr, err := query.conditionString()
+		return String(conditionStr), err
+	}
+	if len(query.queries) == 0 {
+		return "", errors.New("search expression must not be empty")
+	}
+	parts := make([]string, len(query.queries))
+	for i, subQuery := range query.queries {
+		subQueryStr, err := subQuery.ToString()
+		if err != nil {
+			return "", err
+		}
+		parts[i] = string(subQueryStr)
+		if len(subQuery.conjunction) > 0 &&
+			subQuery.conjunction != query.conjunction &&
+			len(subQuery.queries) > 1 {
+			parts[i] = fmt.Sprintf("(%s)", parts[i])
+		}
+	}
+	return String(strings.Join(parts, fmt.Sprintf(" %s ", query.conjunction))), nil
+}
+
+// Validates the search query and sets it as the SearchQuery of the params.
+func (params *FilesFetchParams) SetSearchQuery(query SearchQuery) (err error) {
+	queryStr, err := query.ToString()
+	if err != nil {
+		return err
+	}
+	params.SearchQuery = &queryStr
+	return nil
+}
@@ -0,0 +1,111 @@
+package imagekit
+
+import (
+	"context"
+	"io"
+)
+
+// Represents the operations on files in the media library.
+type FileService interface {
+	GetFiles(params *FilesFetchParams) (fileDetails *[]FileDetails, err error)
+	GetFilesWithContext(
+		ctx context.Context,
+		params *FilesFetchParams) (fileDetails *[]FileDetails, err error)
+	ListAllFiles(
+		ctx context.Context,
+		params *FilesFetchParams,
+		fn func(file FileDetails) error) (err error)
+	GetFileDetails(fileId string) (fileDetail *FileDetails, err error)
+	GetFileDetailsWithContext(ctx context.Context, fileId string) (fileDetail *FileDetails, err error)
+	UpdateFileDetails(fileId string, options *FileOptions) (fileDetail *FileDetails, err error)
+	UpdateFileDetailsWithContext(
+		ctx context.Context,
+		fileId string,
+		options *FileOptions) (fileDetail *FileDetails, err error)
+	GetFileMetadata(fileId string) (metadata *Metadata, err error)
+	GetFileMetadataWithContext(ctx context.Context, fileId string) (metadata *Metadata, err error)
+	DeleteFile(fileId string) (err error)
+	DeleteFileWithContext(ctx context.Context, fileId string) (err error)
+	DeleteFiles(fileIds []string) (deletedFileIds []string, err error)
+	DeleteFilesWithContext(ctx context.Context, fileIds []string) (deletedFileIds []string, err error)
+	CopyFile(srcFilePath, destFolderPath string) (err error)
+	CopyFileWithContext(ctx context.Context, srcFilePath, destFolderPath string) (err error)
+	MoveFile(srcFilePath, destFolderPath string) (err error)
+	MoveFileWithContext(ctx context.Context, srcFilePath, destFolderPath string) (err error)
+	RenameFile(srcFilePath, newFileName string, purgeCache ...bool) (purgeRequestId string, err error)
+	RenameFileWithContext(
+		ctx context.Context,
+		srcFilePath,
+		newFileName string,
+		purgeCache ...bool) (purgeRequestId string, err error)
+}
+
+// Represents the operations on folders in the media library.
+type FolderService interface {
+	CreateFolder(folderName, parentFolderPath string) (err error)
+	CreateFolderWithContext(ctx context.Context, folderName, parentFolderPath string) (err error)
+	DeleteFolder(folderPath string) (err error)
+	DeleteFolderWithContext(ctx context.Context, folderPath string) (err error)
+	CopyFolder(sourceFolderPath, destinationPath string) (jobId string, err error)
+	CopyFolderWithContext(
+		ctx context.Context,
+		sourceFolderPath,
+		destinationPath string) (jobId string, err error)
+	MoveFolder(sourceFolderPath, destinationPath string) (jobId string, err error)
+	MoveFolderWithContext(
+		ctx context.Context,
+		sourceFolderPath,
+		destinationPath string) (jobId string, err error)
+}
+
+// Represents the operations on the tags of files.
+type TagService interface {
+	AddTags(fileIds, tags []string) (updatedFileIds []string, err error)
+	AddTagsWithContext(ctx context.Context, fileIds, tags []string) (updatedFileIds []string, err error)
+	RemoveTags(fileIds, tags []string) (updatedFileIds []string, err error)
+	RemoveTagsWithContext(ctx context.Context, fileIds, tags []string) (updatedFileIds []string, err error)
+	RemoveAITags(fileIds, aiTags []string) (updatedFileIds []string, err error)
+	RemoveAITagsWithContext(ctx context.Context, fileIds, aiTags []string) (updatedFileIds []string, err error)
+}
+
+// Represents the operations on bulk jobs.
+type BulkJobService interface {
+	GetBulkJobStatus(jobId string) (jobDetails *JobDetails, err error)
+	GetBulkJobStatusWithContext(ctx context.Context, jobId string) (jobDetails *JobDetails, err error)
+	WaitForBulkJob(
+		ctx context.Context,
+		jobId string,
+		options *BulkJobWaitOptions) (jobDetails *JobDetails, err error)
+}
+
+// Represents the operations for uploading files.
+type Uploader interface {
+	Upload(file, fileName string, options *FileOptions) (result *FileDetails, err error)
+	UploadWithContext(
+		ctx context.Context,
+		file,
+		fileName string,
+		options *FileOptions) (result *FileDetails, err error)
+	UploadReader(
+		ctx context.Context,
+		reader io.Reader,
+		fileName string,
+		options *FileOptions) (result *FileDetails, err error)
+}
+
+// Represents the operations on the CDN cache.
+type CacheService interface {
+	PurgeCache(fileUrl string) (requestId string, err error)
+	PurgeCacheWithContext(ctx context.Context, fileUrl string) (requestId string, err error)
+	GetPurgeCacheStatus(requestId string) (status string, err error)
+	GetPurgeCacheStatusWithContext(ctx context.Context, requestId string) (status string, err error)
+}
+
+var (
+	_ FileService    = (*ImageKit)(nil)
+	_ FolderService  = (*ImageKit)(nil)
+	_ TagService     = (*ImageKit)(nil)
+	_ BulkJobService = (*ImageKit)(nil)
+	_ Uploader       = (*ImageKit)(nil)
+	_ CacheService   = (*ImageKit)(nil)
+)
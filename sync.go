@@ -0,0 +1,494 @@
+package imagekit
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	SYNC_COMPARE_SIZE     = "size"
+	SYNC_COMPARE_CHECKSUM = "checksum"
+)
+
+var VALID_SYNC_COMPARISONS = []string{SYNC_COMPARE_SIZE, SYNC_COMPARE_CHECKSUM}
+
+const (
+	SYNC_ACTION_UPLOAD = "upload"
+	SYNC_ACTION_UPDATE = "update"
+	SYNC_ACTION_DELETE = "delete"
+)
+
+const (
+	DEFAULT_SYNC_CONCURRENCY = 4
+	MAX_DELETE_FILES_BATCH   = 100
+	// The time a signed URL to a private file stays valid for while its
+	// checksum is computed.
+	SYNC_SIGNED_URL_EXPIRE_SECONDS = 300
+)
+
+// Represents options for syncing a local directory to a media library
+// folder.
+type SyncOptions struct {
+	// How files with the same path are compared, which is one of
+	// VALID_SYNC_COMPARISONS and defaults to SYNC_COMPARE_SIZE. Checksums
+	// are only computed for files of equal size, by downloading the
+	// original of the remote file.
+	Compare string
+	// Whether to delete remote files that do not exist locally.
+	Delete bool
+	// Whether to only plan the actions and print them to PlanWriter.
+	DryRun bool
+	// The writer the plan of a dry run is printed to, which defaults to
+	// os.Stdout.
+	PlanWriter io.Writer
+	// The maximum number of concurrent comparisons and uploads, which
+	// defaults to DEFAULT_SYNC_CONCURRENCY.
+	Concurrency int
+	// Glob patterns of files to skip, matched against both the path
+	// relative to the directory or folder and the base name. Excluded
+	// remote files are never deleted.
+	Exclude []string
+	// Options for the uploaded files. Folder, UseUniqueFileName and
+	// OverwriteFile are set by the sync.
+	FileOptions *FileOptions
+}
+
+// Represents an action of a sync.
+type SyncAction struct {
+	// The type of action, which is one of the SYNC_ACTION_* constants.
+	Type string
+	// The path of the local file, which is empty for deletions.
+	LocalPath string
+	// The path of the file in the media library.
+	RemotePath string
+	// The ID of the remote file, which is empty for new uploads.
+	FileId string
+	Size   int64
+}
+
+// Represents the outcome of a sync.
+type SyncResult struct {
+	// The actions that were performed, or planned in a dry run.
+	Actions []SyncAction
+	// The number of files that were already in sync.
+	Unchanged int
+}
+
+// Represents a file in a local directory.
+type localSyncFile struct {
+	path string
+	size int64
+}
+
+// Calls fn with the indices up to count using at most concurrency
+// goroutines, stopping at the first error.
+func runConcurrently(
+	ctx context.Context,
+	concurrency,
+	count int,
+	fn func(ctx context.Context, i int) error) (err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	indices := make(chan int)
+	var mutex sync.Mutex
+	var firstErr error
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				if err := fn(ctx, i); err != nil {
+					mutex.Lock()
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+					mutex.Unlock()
+				}
+			}
+		}()
+	}
+feed:
+	for i := 0; i < count; i++ {
+		select {
+		case indices <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indices)
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// Checks if a relative path matches any of the glob patterns.
+func isExcluded(relPath string, patterns []string) (excluded bool, err error) {
+	for _, pattern := range patterns {
+		for _, name := range []string{relPath, path.Base(relPath)} {
+			matched, err := path.Match(pattern, name)
+			if err != nil {
+				return false, err
+			}
+			if matched {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// Lists the regular files in a directory by their slash-separated paths
+// relative to it.
+func listLocalSyncFiles(
+	localDir string,
+	exclude []string) (files map[string]localSyncFile, err error) {
+	files = make(map[string]localSyncFile)
+	err = filepath.WalkDir(localDir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		relPath, err := filepath.Rel(localDir, filePath)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+		excluded, err := isExcluded(relPath, exclude)
+		if err != nil || excluded {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		files[relPath] = localSyncFile{path: filePath, size: info.Size()}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// Computes the SHA-256 checksum of a local file.
+func localChecksum(filePath string) (checksum []byte, err error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err = io.Copy(hash, file); err != nil {
+		return nil, err
+	}
+	return hash.Sum(nil), nil
+}
+
+// Computes the SHA-256 checksum of the original of a file in the media
+// library by downloading it.
+func (imgKit *ImageKit) remoteChecksum(
+	ctx context.Context,
+	file FileDetails) (checksum []byte, err error) {
+	if file.Url == nil {
+		return nil, errors.New("file has no url")
+	}
+	original := Bool(true)
+	options := &UrlOptions{
+		Src:             file.Url,
+		Transformations: &[]Transformation{{Original: &original}},
+	}
+	if file.IsPrivateFile != nil && bool(*file.IsPrivateFile) {
+		signed := Bool(true)
+		expireSeconds := Int32(SYNC_SIGNED_URL_EXPIRE_SECONDS)
+		options.Signed = &signed
+		options.ExpireSeconds = &expireSeconds
+	}
+	fileUrl, err := imgKit.URL(options)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", imgKit.getUserAgent())
+	res, err := imgKit.getHttpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s (status %d)", *file.FilePath, res.StatusCode)
+	}
+	hash := sha256.New()
+	if _, err = io.Copy(hash, res.Body); err != nil {
+		return nil, err
+	}
+	return hash.Sum(nil), nil
+}
+
+// Lists the files in a media library folder and its subfolders by their
+// slash-separated paths relative to it, listing each subfolder in turn.
+func (imgKit *ImageKit) listRemoteSyncFiles(
+	ctx context.Context,
+	remoteFolder string) (files map[string]FileDetails, err error) {
+	remotePrefix := strings.TrimSuffix(remoteFolder, "/") + "/"
+	files = make(map[string]FileDetails)
+	listed := map[string]bool{remoteFolder: true}
+	folders := []string{remoteFolder}
+	listType := String("all")
+	for len(folders) > 0 {
+		folder := folders[0]
+		folders = folders[1:]
+		folderPath := String(folder)
+		err = imgKit.ListAllFiles(ctx, &FilesFetchParams{Type: &listType, Path: &folderPath}, func(file FileDetails) error {
+			if file.Type != nil && *file.Type == "folder" {
+				subfolder := ""
+				if file.FolderPath != nil {
+					subfolder = string(*file.FolderPath)
+				} else if file.Name != nil {
+					subfolder = path.Join(folder, string(*file.Name))
+				}
+				subfolder = "/" + strings.Trim(subfolder, "/")
+				if strings.HasPrefix(subfolder, remotePrefix) && !listed[subfolder] {
+					listed[subfolder] = true
+					folders = append(folders, subfolder)
+				}
+				return nil
+			}
+			if file.FilePath == nil || file.FileId == nil {
+				return nil
+			}
+			if filePath := string(*file.FilePath); strings.HasPrefix(filePath, remotePrefix) {
+				files[strings.TrimPrefix(filePath, remotePrefix)] = file
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// Writes the actions of a sync plan, one per line.
+func writeSyncPlan(writer io.Writer, result *SyncResult) (err error) {
+	for _, action := range result.Actions {
+		if action.Type == SYNC_ACTION_DELETE {
+			_, err = fmt.Fprintf(writer, "%s %s\n", action.Type, action.RemotePath)
+		} else {
+			_, err = fmt.Fprintf(writer, "%s %s -> %s\n", action.Type, action.LocalPath, action.RemotePath)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(writer, "%d unchanged\n", result.Unchanged)
+	return err
+}
+
+// Syncs the files of a local directory and its subdirectories to a folder
+// in the media library, uploading files that are new or changed and
+// optionally deleting remote files that do not exist locally. Files are
+// matched by their paths relative to the directory and folder. If an upload
+// or deletion fails, the result of the actions performed before the
+// failure is returned along with the error.
+func (imgKit *ImageKit) Sync(
+	ctx context.Context,
+	localDir,
+	remoteFolder string,
+	options *SyncOptions) (result *SyncResult, err error) {
+	if options == nil {
+		options = &SyncOptions{}
+	}
+	compare := String(options.Compare)
+	if len(compare) == 0 {
+		compare = SYNC_COMPARE_SIZE
+	}
+	if !compare.StringInArray(VALID_SYNC_COMPARISONS) {
+		return nil, errors.New("invalid compare value")
+	}
+	concurrency := options.Concurrency
+	if concurrency == 0 {
+		concurrency = DEFAULT_SYNC_CONCURRENCY
+	}
+	if concurrency < 0 {
+		return nil, errors.New("concurrency is out of bounds")
+	}
+	remoteFolder = "/" + strings.Trim(remoteFolder, "/")
+	localFiles, err := listLocalSyncFiles(localDir, options.Exclude)
+	if err != nil {
+		return nil, err
+	}
+	remoteFiles, err := imgKit.listRemoteSyncFiles(ctx, remoteFolder)
+	if err != nil {
+		return nil, err
+	}
+	relPaths := []string{}
+	for relPath := range localFiles {
+		relPaths = append(relPaths, relPath)
+	}
+	sort.Strings(relPaths)
+	result = &SyncResult{}
+	candidates := []SyncAction{}
+	candidateRemotes := []FileDetails{}
+	for _, relPath := range relPaths {
+		local := localFiles[relPath]
+		action := SyncAction{
+			Type:       SYNC_ACTION_UPLOAD,
+			LocalPath:  local.path,
+			RemotePath: path.Join(remoteFolder, relPath),
+			Size:       local.size,
+		}
+		remote, ok := remoteFiles[relPath]
+		switch {
+		case !ok:
+			result.Actions = append(result.Actions, action)
+			continue
+		case int64(remote.Size) != local.size:
+			action.Type = SYNC_ACTION_UPDATE
+			action.FileId = string(*remote.FileId)
+			result.Actions = append(result.Actions, action)
+		case compare == SYNC_COMPARE_CHECKSUM:
+			action.Type = SYNC_ACTION_UPDATE
+			action.FileId = string(*remote.FileId)
+			candidates = append(candidates, action)
+			candidateRemotes = append(candidateRemotes, remote)
+		default:
+			result.Unchanged++
+		}
+	}
+	changed := make([]bool, len(candidates))
+	err = runConcurrently(ctx, concurrency, len(candidates), func(ctx context.Context, i int) error {
+		localSum, err := localChecksum(candidates[i].LocalPath)
+		if err != nil {
+			return err
+		}
+		remoteSum, err := imgKit.remoteChecksum(ctx, candidateRemotes[i])
+		if err != nil {
+			return err
+		}
+		changed[i] = !bytes.Equal(localSum, remoteSum)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, action := range candidates {
+		if changed[i] {
+			result.Actions = append(result.Actions, action)
+		} else {
+			result.Unchanged++
+		}
+	}
+	sort.SliceStable(result.Actions, func(i, j int) bool {
+		return result.Actions[i].RemotePath < result.Actions[j].RemotePath
+	})
+	uploadCount := len(result.Actions)
+	if options.Delete {
+		orphans := []string{}
+		for relPath := range remoteFiles {
+			if _, ok := localFiles[relPath]; ok {
+				continue
+			}
+			excluded, err := isExcluded(relPath, options.Exclude)
+			if err != nil {
+				return nil, err
+			}
+			if !excluded {
+				orphans = append(orphans, relPath)
+			}
+		}
+		sort.Strings(orphans)
+		for _, relPath := range orphans {
+			remote := remoteFiles[relPath]
+			result.Actions = append(result.Actions, SyncAction{
+				Type:       SYNC_ACTION_DELETE,
+				RemotePath: string(*remote.FilePath),
+				FileId:     string(*remote.FileId),
+				Size:       int64(remote.Size),
+			})
+		}
+	}
+	if options.DryRun {
+		writer := options.PlanWriter
+		if writer == nil {
+			writer = os.Stdout
+		}
+		if err = writeSyncPlan(writer, result); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+	uploaded := make([]bool, uploadCount)
+	performed := func(deleted int) *SyncResult {
+		partial := &SyncResult{Unchanged: result.Unchanged}
+		for i, action := range result.Actions[:uploadCount] {
+			if uploaded[i] {
+				partial.Actions = append(partial.Actions, action)
+			}
+		}
+		partial.Actions = append(partial.Actions, result.Actions[uploadCount:uploadCount+deleted]...)
+		return partial
+	}
+	err = runConcurrently(ctx, concurrency, uploadCount, func(ctx context.Context, i int) error {
+		action := result.Actions[i]
+		fileOptions := FileOptions{}
+		if options.FileOptions != nil {
+			fileOptions = *options.FileOptions
+		}
+		folder := String(path.Dir(action.RemotePath))
+		useUniqueFileName := Bool(false)
+		overwriteFile := Bool(true)
+		fileOptions.Folder = &folder
+		fileOptions.UseUniqueFileName = &useUniqueFileName
+		fileOptions.OverwriteFile = &overwriteFile
+		file, err := os.Open(action.LocalPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		_, err = imgKit.UploadReader(ctx, file, path.Base(action.RemotePath), &fileOptions)
+		if err != nil {
+			return fmt.Errorf("failed to upload %s: %w", action.LocalPath, err)
+		}
+		uploaded[i] = true
+		return nil
+	})
+	if err != nil {
+		return performed(0), err
+	}
+	deletions := result.Actions[uploadCount:]
+	for start := 0; start < len(deletions); start += MAX_DELETE_FILES_BATCH {
+		end := start + MAX_DELETE_FILES_BATCH
+		if end > len(deletions) {
+			end = len(deletions)
+		}
+		fileIds := []string{}
+		for _, action := range deletions[start:end] {
+			fileIds = append(fileIds, action.FileId)
+		}
+		if _, err = imgKit.DeleteFilesWithContext(ctx, fileIds); err != nil {
+			return performed(start), err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,123 @@
+package imagekit_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	imagekit "github.com/B3zaleel/imagekit-go"
+	"github.com/B3zaleel/imagekit-go/imagekittest"
+)
+
+// Writes files to a temporary directory by their slash-separated paths.
+func writeSyncFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		filePath := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestSyncNestedFiles(t *testing.T) {
+	server := imagekittest.NewServer()
+	defer server.Close()
+	server.AddFile("a.jpg", "/site", []byte("aaaa"))
+	server.AddFile("b.jpg", "/site/nested/deeper", []byte("bbbb"))
+	server.AddFile("orphan.jpg", "/site/nested", []byte("orphan"))
+	server.AddFile("other.jpg", "/other", []byte("other"))
+	localDir := writeSyncFiles(t, map[string]string{
+		"a.jpg":               "aaaa",
+		"nested/deeper/b.jpg": "bbbb",
+		"nested/c.jpg":        "cccc",
+	})
+	result, err := server.ImageKit().Sync(
+		context.Background(), localDir, "/site", &imagekit.SyncOptions{Delete: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Unchanged != 2 {
+		t.Errorf("Unchanged = %d, want 2", result.Unchanged)
+	}
+	actions := map[string]string{}
+	for _, action := range result.Actions {
+		actions[action.RemotePath] = action.Type
+	}
+	want := map[string]string{
+		"/site/nested/c.jpg":      imagekit.SYNC_ACTION_UPLOAD,
+		"/site/nested/orphan.jpg": imagekit.SYNC_ACTION_DELETE,
+	}
+	if len(actions) != len(want) {
+		t.Errorf("actions = %v, want %v", actions, want)
+	}
+	for remotePath, actionType := range want {
+		if actions[remotePath] != actionType {
+			t.Errorf("action for %s = %q, want %q", remotePath, actions[remotePath], actionType)
+		}
+	}
+}
+
+func TestSyncUploadFailureReturnsPartialResult(t *testing.T) {
+	server := imagekittest.NewServer()
+	defer server.Close()
+	server.AddFile("a.jpg", "/site/nested", []byte("aaaa"))
+	server.InjectFault(imagekittest.Fault{
+		Method:     http.MethodPost,
+		PathPrefix: imagekittest.UPLOAD_PATH,
+		StatusCode: http.StatusBadRequest,
+		Body:       `{"message": "Invalid file."}`,
+	})
+	localDir := writeSyncFiles(t, map[string]string{
+		"nested/a.jpg": "aaaa",
+		"b.jpg":        "bbbb",
+	})
+	result, err := server.ImageKit().Sync(context.Background(), localDir, "/site", nil)
+	if err == nil {
+		t.Fatal("expected an upload error")
+	}
+	if result == nil {
+		t.Fatal("expected a partial result")
+	}
+	if result.Unchanged != 1 || len(result.Actions) != 0 {
+		t.Errorf("result = %+v, want 1 unchanged file and no actions", result)
+	}
+}
+
+func TestSyncKeepsExcludedRemoteFiles(t *testing.T) {
+	server := imagekittest.NewServer()
+	defer server.Close()
+	server.AddFile("notes.txt", "/site", []byte("remote notes"))
+	server.AddFile("draft.jpg", "/site/nested", []byte("remote draft"))
+	server.AddFile("orphan.jpg", "/site", []byte("orphan"))
+	localDir := writeSyncFiles(t, map[string]string{
+		"notes.txt":        "local notes",
+		"nested/draft.jpg": "local draft",
+	})
+	result, err := server.ImageKit().Sync(context.Background(), localDir, "/site", &imagekit.SyncOptions{
+		Delete:  true,
+		Exclude: []string{"*.txt", "nested/draft.jpg"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Actions) != 1 || result.Actions[0].Type != imagekit.SYNC_ACTION_DELETE ||
+		result.Actions[0].RemotePath != "/site/orphan.jpg" {
+		t.Errorf("actions = %+v, want only the deletion of /site/orphan.jpg", result.Actions)
+	}
+	remaining := map[string]bool{}
+	for _, file := range server.Files() {
+		remaining[string(*file.FilePath)] = true
+	}
+	for _, filePath := range []string{"/site/notes.txt", "/site/nested/draft.jpg"} {
+		if !remaining[filePath] {
+			t.Errorf("excluded file %s was deleted", filePath)
+		}
+	}
+}
@@ -2,11 +2,14 @@ package imagekit
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math/rand"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
@@ -20,14 +23,27 @@ func (imgKit *ImageKit) Upload(
 	file,
 	fileName string,
 	options *FileOptions) (result *FileDetails, err error) {
+	return imgKit.UploadWithContext(context.Background(), file, fileName, options)
+}
+
+// Uploads a file to ImageKit.io using the given context.
+func (imgKit *ImageKit) UploadWithContext(
+	ctx context.Context,
+	file,
+	fileName string,
+	options *FileOptions) (result *FileDetails, err error) {
+	if err = imgKit.validateCustomMetadata(ctx, options); err != nil {
+		return nil, err
+	}
 	boundary := fmt.Sprintf("%s%s", strings.Repeat("-", 15), getRandomHex(32))
 	body, err := getBody(file, fileName, boundary, options)
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodPost,
-		UPLOAD_URL,
+		imgKit.getUploadUrl(),
 		bytes.NewBufferString(body),
 	)
 	if err != nil {
@@ -52,6 +68,82 @@ func (imgKit *ImageKit) Upload(
 	return result, nil
 }
 
+// Uploads the contents of a reader to ImageKit.io, streaming the binary
+// content in a multipart body without buffering it in memory.
+func (imgKit *ImageKit) UploadReader(
+	ctx context.Context,
+	reader io.Reader,
+	fileName string,
+	options *FileOptions) (result *FileDetails, err error) {
+	if reader == nil {
+		return nil, errors.New("reader must not be nil")
+	}
+	if len(strings.TrimSpace(fileName)) == 0 {
+		return nil, errors.New("fileName must not be empty")
+	}
+	if err = imgKit.validateCustomMetadata(ctx, options); err != nil {
+		return nil, err
+	}
+	dataFields := make(map[string]string)
+	if options != nil {
+		dataFields, err = options.ToDict()
+		if err != nil {
+			return nil, err
+		}
+	}
+	dataFields["fileName"] = fileName
+	pipeReader, pipeWriter := io.Pipe()
+	defer pipeReader.Close()
+	formWriter := multipart.NewWriter(pipeWriter)
+	go func() {
+		pipeWriter.CloseWithError(
+			writeMultipartBody(formWriter, reader, fileName, dataFields),
+		)
+	}()
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		imgKit.getUploadUrl(),
+		pipeReader,
+	)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", formWriter.FormDataContentType())
+	bodyStr, err := imgKit.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	result = &FileDetails{}
+	err = json.Unmarshal([]byte(bodyStr), result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Writes the data fields and file contents of an upload request to a
+// multipart writer.
+func writeMultipartBody(
+	formWriter *multipart.Writer,
+	reader io.Reader,
+	fileName string,
+	dataFields map[string]string) (err error) {
+	for key, val := range dataFields {
+		if err = formWriter.WriteField(key, val); err != nil {
+			return err
+		}
+	}
+	fileWriter, err := formWriter.CreateFormFile("file", fileName)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(fileWriter, reader); err != nil {
+		return err
+	}
+	return formWriter.Close()
+}
+
 // Checks the given file and fileName and transforms the file if necessary.
 func getFile(
 	file,
@@ -0,0 +1,408 @@
+package imagekit
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	TRANSFORMATION_POSITION_PATH  = "path"
+	TRANSFORMATION_POSITION_QUERY = "query"
+	TRANSFORMATION_QUERY_PARAM    = "tr"
+	CHAINED_TRANSFORMATION_SEP    = ":"
+	TRANSFORMATION_PARAM_SEP      = ","
+	SIGNATURE_QUERY_PARAM         = "ik-s"
+	EXPIRY_QUERY_PARAM            = "ik-t"
+	DEFAULT_EXPIRY_TIMESTAMP      = 9999999999
+)
+
+var VALID_TRANSFORMATION_POSITIONS = []string{
+	TRANSFORMATION_POSITION_PATH,
+	TRANSFORMATION_POSITION_QUERY,
+}
+var VALID_CROP_VALUES = []string{
+	"force",
+	"at_max",
+	"at_max_enlarge",
+	"at_least",
+	"maintain_ratio",
+}
+var VALID_CROP_MODES = []string{"pad_resize", "extract", "pad_extract"}
+var VALID_FORMATS = []string{
+	"auto",
+	"webp",
+	"jpg",
+	"jpeg",
+	"png",
+	"gif",
+	"svg",
+	"avif",
+	"mp4",
+	"webm",
+	"orig",
+}
+
+// Represents a single step in a chain of transformations.
+type Transformation struct {
+	Width, Height       *Float64
+	AspectRatio         *String
+	Crop, CropMode      *String
+	Focus               *String
+	X, Y                *Int32
+	XCenter, YCenter    *Int32
+	Quality             *Int32
+	Format              *String
+	Blur                *Int32
+	Radius              *String
+	Rotation            *String
+	Background          *String
+	Border              *String
+	DPR                 *Float64
+	NamedTransformation *String
+	DefaultImage        *String
+	Trim                *String
+	Progressive         *Bool
+	Lossless            *Bool
+	Metadata            *Bool
+	ColorProfile        *Bool
+	Grayscale           *Bool
+	Original            *Bool
+	Raw                 *String
+}
+
+// Represents options for generating a URL to a file.
+type UrlOptions struct {
+	Path, Src, UrlEndpoint *String
+	Transformations        *[]Transformation
+	TransformationPosition *String
+	QueryParameters        map[string]string
+	Signed                 *Bool
+	ExpireSeconds          *Int32
+}
+
+// Formats a typed floating point value without trailing zeros.
+func formatFloat(value Float64) string {
+	return strconv.FormatFloat(float64(value), 'f', -1, 64)
+}
+
+// Converts the transformation to its URL representation.
+func (transformation Transformation) ToString() (str string, err error) {
+	params := []string{}
+	paramPut := func(key, value string) {
+		params = append(params, fmt.Sprintf("%s-%s", key, value))
+	}
+	if transformation.Width != nil {
+		paramPut("w", formatFloat(*transformation.Width))
+	}
+	if transformation.Height != nil {
+		paramPut("h", formatFloat(*transformation.Height))
+	}
+	if transformation.AspectRatio != nil {
+		paramPut("ar", string(*transformation.AspectRatio))
+	}
+	if transformation.Crop != nil {
+		if !(*transformation.Crop).StringInArray(VALID_CROP_VALUES) {
+			return "", errors.New("invalid crop value")
+		}
+		paramPut("c", string(*transformation.Crop))
+	}
+	if transformation.CropMode != nil {
+		if !(*transformation.CropMode).StringInArray(VALID_CROP_MODES) {
+			return "", errors.New("invalid crop mode value")
+		}
+		paramPut("cm", string(*transformation.CropMode))
+	}
+	if transformation.Focus != nil {
+		paramPut("fo", string(*transformation.Focus))
+	}
+	if transformation.X != nil {
+		paramPut("x", strconv.Itoa(int(*transformation.X)))
+	}
+	if transformation.Y != nil {
+		paramPut("y", strconv.Itoa(int(*transformation.Y)))
+	}
+	if transformation.XCenter != nil {
+		paramPut("xc", strconv.Itoa(int(*transformation.XCenter)))
+	}
+	if transformation.YCenter != nil {
+		paramPut("yc", strconv.Itoa(int(*transformation.YCenter)))
+	}
+	if transformation.Quality != nil {
+		if *transformation.Quality < 1 || *transformation.Quality > 100 {
+			return "", errors.New("quality is out of bounds")
+		}
+		paramPut("q", strconv.Itoa(int(*transformation.Quality)))
+	}
+	if transformation.Format != nil {
+		if !(*transformation.Format).StringInArray(VALID_FORMATS) {
+			return "", errors.New("invalid format value")
+		}
+		paramPut("f", string(*transformation.Format))
+	}
+	if transformation.Blur != nil {
+		if *transformation.Blur < 1 || *transformation.Blur > 100 {
+			return "", errors.New("blur is out of bounds")
+		}
+		paramPut("bl", strconv.Itoa(int(*transformation.Blur)))
+	}
+	if transformation.Radius != nil {
+		paramPut("r", string(*transformation.Radius))
+	}
+	if transformation.Rotation != nil {
+		paramPut("rt", string(*transformation.Rotation))
+	}
+	if transformation.Background != nil {
+		paramPut("bg", string(*transformation.Background))
+	}
+	if transformation.Border != nil {
+		paramPut("b", string(*transformation.Border))
+	}
+	if transformation.DPR != nil {
+		paramPut("dpr", formatFloat(*transformation.DPR))
+	}
+	if transformation.NamedTransformation != nil {
+		paramPut("n", string(*transformation.NamedTransformation))
+	}
+	if transformation.DefaultImage != nil {
+		paramPut("di", string(*transformation.DefaultImage))
+	}
+	if transformation.Trim != nil {
+		paramPut("t", string(*transformation.Trim))
+	}
+	if transformation.Progressive != nil {
+		paramPut("pr", fmt.Sprintf("%t", *transformation.Progressive))
+	}
+	if transformation.Lossless != nil {
+		paramPut("lo", fmt.Sprintf("%t", *transformation.Lossless))
+	}
+	if transformation.Metadata != nil {
+		paramPut("md", fmt.Sprintf("%t", *transformation.Metadata))
+	}
+	if transformation.ColorProfile != nil {
+		paramPut("cp", fmt.Sprintf("%t", *transformation.ColorProfile))
+	}
+	if transformation.Grayscale != nil && *transformation.Grayscale {
+		params = append(params, "e-grayscale")
+	}
+	if transformation.Original != nil {
+		paramPut("orig", fmt.Sprintf("%t", *transformation.Original))
+	}
+	if transformation.Raw != nil && len(*transformation.Raw) > 0 {
+		params = append(params, string(*transformation.Raw))
+	}
+	return strings.Join(params, TRANSFORMATION_PARAM_SEP), nil
+}
+
+// Converts a chain of transformations to its URL representation.
+func buildTransformationString(
+	transformations []Transformation) (str string, err error) {
+	steps := []string{}
+	for _, transformation := range transformations {
+		step, err := transformation.ToString()
+		if err != nil {
+			return "", err
+		}
+		if len(step) > 0 {
+			steps = append(steps, step)
+		}
+	}
+	return strings.Join(steps, CHAINED_TRANSFORMATION_SEP), nil
+}
+
+// Generates a URL to a file using the given options.
+func (imgKit *ImageKit) URL(options *UrlOptions) (urlStr string, err error) {
+	if options == nil {
+		return "", errors.New("options must not be nil")
+	}
+	transformationStr := ""
+	if options.Transformations != nil {
+		transformationStr, err = buildTransformationString(
+			*options.Transformations,
+		)
+		if err != nil {
+			return "", err
+		}
+	}
+	position := String(TRANSFORMATION_POSITION_PATH)
+	if options.TransformationPosition != nil {
+		position = *options.TransformationPosition
+		if !position.StringInArray(VALID_TRANSFORMATION_POSITIONS) {
+			return "", errors.New("invalid transformation position value")
+		}
+	}
+	var fileUrl *url.URL
+	query := url.Values{}
+	if options.Path != nil && len(strings.TrimSpace(string(*options.Path))) > 0 {
+		urlEndpoint := imgKit.UrlEndpoint
+		if options.UrlEndpoint != nil {
+			urlEndpoint = string(*options.UrlEndpoint)
+		}
+		if len(strings.TrimSpace(urlEndpoint)) == 0 {
+			return "", errors.New("urlEndpoint must not be empty")
+		}
+		fileUrl, err = url.Parse(urlEndpoint)
+		if err != nil {
+			return "", err
+		}
+		pathUrl, err := url.Parse(strings.TrimLeft(string(*options.Path), "/"))
+		if err != nil {
+			return "", err
+		}
+		segments := []string{strings.TrimRight(fileUrl.Path, "/")}
+		if len(transformationStr) > 0 && position == TRANSFORMATION_POSITION_PATH {
+			segments = append(segments, fmt.Sprintf("tr:%s", transformationStr))
+		}
+		segments = append(segments, pathUrl.Path)
+		fileUrl.Path = strings.Join(segments, "/")
+		fileUrl.RawPath = ""
+		query = fileUrl.Query()
+		for key, values := range pathUrl.Query() {
+			query[key] = values
+		}
+	} else if options.Src != nil && len(strings.TrimSpace(string(*options.Src))) > 0 {
+		fileUrl, err = url.Parse(string(*options.Src))
+		if err != nil {
+			return "", err
+		}
+		query = fileUrl.Query()
+		position = TRANSFORMATION_POSITION_QUERY
+	} else {
+		return "", errors.New("either path or src must be provided")
+	}
+	for key, value := range options.QueryParameters {
+		query.Set(key, value)
+	}
+	if len(transformationStr) > 0 && position == TRANSFORMATION_POSITION_QUERY {
+		query.Set(TRANSFORMATION_QUERY_PARAM, transformationStr)
+	}
+	fileUrl.RawQuery = query.Encode()
+	urlStr = fileUrl.String()
+	if options.Signed == nil || !bool(*options.Signed) {
+		return urlStr, nil
+	}
+	if len(imgKit.PrivateKey) == 0 {
+		return "", errors.New("privateKey must not be empty")
+	}
+	expiryTimestamp := int64(DEFAULT_EXPIRY_TIMESTAMP)
+	signedParams := []string{}
+	if options.ExpireSeconds != nil {
+		if *options.ExpireSeconds < 1 {
+			return "", errors.New("expireSeconds is out of bounds")
+		}
+		expiryTimestamp = time.Now().Unix() + int64(*options.ExpireSeconds)
+		signedParams = append(
+			signedParams,
+			fmt.Sprintf("%s=%d", EXPIRY_QUERY_PARAM, expiryTimestamp),
+		)
+	}
+	urlEndpoint := imgKit.UrlEndpoint
+	if options.UrlEndpoint != nil {
+		urlEndpoint = string(*options.UrlEndpoint)
+	}
+	signature := getSignature(
+		imgKit.PrivateKey,
+		urlStr,
+		urlEndpoint,
+		expiryTimestamp,
+	)
+	signedParams = append(
+		signedParams,
+		fmt.Sprintf("%s=%s", SIGNATURE_QUERY_PARAM, signature),
+	)
+	delim := "?"
+	if len(fileUrl.RawQuery) > 0 {
+		delim = "&"
+	}
+	return urlStr + delim + strings.Join(signedParams, "&"), nil
+}
+
+// Computes the signature of a URL relative to the given URL endpoint.
+func getSignature(
+	privateKey,
+	urlStr,
+	urlEndpoint string,
+	expiryTimestamp int64) string {
+	urlEndpoint = fmt.Sprintf("%s/", strings.TrimRight(urlEndpoint, "/"))
+	strToSign := fmt.Sprintf(
+		"%s%d",
+		strings.Replace(urlStr, urlEndpoint, "", 1),
+		expiryTimestamp,
+	)
+	mac := hmac.New(sha1.New, []byte(privateKey))
+	mac.Write([]byte(strToSign))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verifies the signature and expiry time of a signed URL generated with
+// the URL endpoint of the ImageKit instance.
+func (imgKit *ImageKit) VerifySignedURL(signedUrl string) (err error) {
+	return imgKit.VerifySignedURLWithEndpoint(signedUrl, imgKit.UrlEndpoint)
+}
+
+// Verifies the signature and expiry time of a signed URL generated with
+// the given URL endpoint, such as the UrlEndpoint of its UrlOptions.
+func (imgKit *ImageKit) VerifySignedURLWithEndpoint(signedUrl, urlEndpoint string) (err error) {
+	if len(imgKit.PrivateKey) == 0 {
+		return errors.New("privateKey must not be empty")
+	}
+	baseUrl, rawQuery := signedUrl, ""
+	if i := strings.Index(signedUrl, "?"); i >= 0 {
+		baseUrl, rawQuery = signedUrl[:i], signedUrl[i+1:]
+	}
+	if i := strings.Index(rawQuery, "#"); i >= 0 {
+		rawQuery = rawQuery[:i]
+	}
+	signature, expiry := "", ""
+	unsignedParams := []string{}
+	for _, param := range strings.Split(rawQuery, "&") {
+		if len(param) == 0 {
+			continue
+		}
+		key, value := param, ""
+		if i := strings.Index(param, "="); i >= 0 {
+			key, value = param[:i], param[i+1:]
+		}
+		switch key {
+		case SIGNATURE_QUERY_PARAM:
+			signature = value
+		case EXPIRY_QUERY_PARAM:
+			expiry = value
+		default:
+			unsignedParams = append(unsignedParams, param)
+		}
+	}
+	if len(signature) == 0 {
+		return errors.New("url is not signed")
+	}
+	expiryTimestamp := int64(DEFAULT_EXPIRY_TIMESTAMP)
+	if len(expiry) > 0 {
+		expiryTimestamp, err = strconv.ParseInt(expiry, 10, 64)
+		if err != nil {
+			return errors.New("invalid expiry timestamp")
+		}
+		if time.Now().Unix() > expiryTimestamp {
+			return errors.New("url has expired")
+		}
+	}
+	unsignedUrl := baseUrl
+	if len(unsignedParams) > 0 {
+		unsignedUrl = fmt.Sprintf("%s?%s", baseUrl, strings.Join(unsignedParams, "&"))
+	}
+	expectedSignature := getSignature(
+		imgKit.PrivateKey,
+		unsignedUrl,
+		urlEndpoint,
+		expiryTimestamp,
+	)
+	if !hmac.Equal([]byte(signature), []byte(expectedSignature)) {
+		return errors.New("invalid url signature")
+	}
+	return nil
+}
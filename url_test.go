@@ -0,0 +1,220 @@
+package imagekit
+
+import (
+	"strings"
+	"testing"
+)
+
+const (
+	TEST_PRIVATE_KEY  = "private_key_test"
+	TEST_URL_ENDPOINT = "https://ik.imagekit.io/test_url_endpoint"
+)
+
+func TestSignedURL(t *testing.T) {
+	imgKit := New("public_key_test", TEST_PRIVATE_KEY, TEST_URL_ENDPOINT)
+	width, height := Float64(400), Float64(300)
+	path, signed := String("/default-image.jpg"), Bool(true)
+	transformations := []Transformation{{Width: &width, Height: &height}}
+	tests := []struct {
+		position String
+		want     string
+	}{
+		{
+			TRANSFORMATION_POSITION_PATH,
+			TEST_URL_ENDPOINT + "/tr:w-400,h-300/default-image.jpg?ik-s=6fe59773101d5548893ceb900459ab75a0f737ff",
+		},
+		{
+			TRANSFORMATION_POSITION_QUERY,
+			TEST_URL_ENDPOINT + "/default-image.jpg?tr=w-400%2Ch-300&ik-s=2ecaf8da7ce85b345baf71144ef711c7e06a8c81",
+		},
+	}
+	for _, test := range tests {
+		position := test.position
+		signedUrl, err := imgKit.URL(&UrlOptions{
+			Path:                   &path,
+			Transformations:        &transformations,
+			TransformationPosition: &position,
+			Signed:                 &signed,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if signedUrl != test.want {
+			t.Errorf("URL = %s, want %s", signedUrl, test.want)
+		}
+		if err = imgKit.VerifySignedURL(signedUrl); err != nil {
+			t.Errorf("VerifySignedURL(%s) = %v", signedUrl, err)
+		}
+	}
+}
+
+func TestSignedURLWithCustomEndpoint(t *testing.T) {
+	imgKit := New("public_key_test", TEST_PRIVATE_KEY, TEST_URL_ENDPOINT)
+	path, urlEndpoint, signed := String("photos/default-image.jpg"), String("https://media.example.com"), Bool(true)
+	signedUrl, err := imgKit.URL(&UrlOptions{Path: &path, UrlEndpoint: &urlEndpoint, Signed: &signed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "https://media.example.com/photos/default-image.jpg?ik-s=89d2d393e8b543ab3f8b2df4e3ed1311755b74f1"
+	if signedUrl != want {
+		t.Errorf("URL = %s, want %s", signedUrl, want)
+	}
+	if err = imgKit.VerifySignedURLWithEndpoint(signedUrl, string(urlEndpoint)); err != nil {
+		t.Errorf("VerifySignedURLWithEndpoint = %v", err)
+	}
+	if err = imgKit.VerifySignedURL(signedUrl); err == nil {
+		t.Error("VerifySignedURL accepted a URL signed for another endpoint")
+	}
+}
+
+func TestVerifySignedURLExpiry(t *testing.T) {
+	imgKit := New("public_key_test", TEST_PRIVATE_KEY, TEST_URL_ENDPOINT)
+	unexpired := TEST_URL_ENDPOINT + "/tr:w-400,h-300/default-image.jpg?ik-t=4102444800&ik-s=c7669b027267913a818010e9dc4085aaaaf40a19"
+	if err := imgKit.VerifySignedURL(unexpired); err != nil {
+		t.Errorf("VerifySignedURL(%s) = %v", unexpired, err)
+	}
+	expired := TEST_URL_ENDPOINT + "/tr:w-400,h-300/default-image.jpg?ik-t=1000000000&ik-s=c7669b027267913a818010e9dc4085aaaaf40a19"
+	if err := imgKit.VerifySignedURL(expired); err == nil || err.Error() != "url has expired" {
+		t.Errorf("VerifySignedURL(%s) = %v, want an expiry error", expired, err)
+	}
+	path, signed, expireSeconds := String("/default-image.jpg"), Bool(true), Int32(60)
+	signedUrl, err := imgKit.URL(&UrlOptions{Path: &path, Signed: &signed, ExpireSeconds: &expireSeconds})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(signedUrl, "?ik-t=") {
+		t.Errorf("URL %s has no expiry timestamp", signedUrl)
+	}
+	if err = imgKit.VerifySignedURL(signedUrl); err != nil {
+		t.Errorf("VerifySignedURL(%s) = %v", signedUrl, err)
+	}
+}
+
+func TestVerifySignedURLRejectsTamperedURLs(t *testing.T) {
+	imgKit := New("public_key_test", TEST_PRIVATE_KEY, TEST_URL_ENDPOINT)
+	tampered := []string{
+		TEST_URL_ENDPOINT + "/tr:w-800,h-300/default-image.jpg?ik-s=6fe59773101d5548893ceb900459ab75a0f737ff",
+		TEST_URL_ENDPOINT + "/tr:w-400,h-300/other-image.jpg?ik-s=6fe59773101d5548893ceb900459ab75a0f737ff",
+		TEST_URL_ENDPOINT + "/tr:w-400,h-300/default-image.jpg?ik-s=6fe59773101d5548893ceb900459ab75a0f737fe",
+		TEST_URL_ENDPOINT + "/tr:w-400,h-300/default-image.jpg?ik-t=4102444801&ik-s=c7669b027267913a818010e9dc4085aaaaf40a19",
+		TEST_URL_ENDPOINT + "/tr:w-400,h-300/default-image.jpg?v=2&ik-s=6fe59773101d5548893ceb900459ab75a0f737ff",
+		TEST_URL_ENDPOINT + "/tr:w-400,h-300/default-image.jpg",
+	}
+	for _, signedUrl := range tampered {
+		if err := imgKit.VerifySignedURL(signedUrl); err == nil {
+			t.Errorf("VerifySignedURL(%s) accepted a tampered URL", signedUrl)
+		}
+	}
+	other := New("public_key_test", "other_private_key", TEST_URL_ENDPOINT)
+	signedUrl := TEST_URL_ENDPOINT + "/tr:w-400,h-300/default-image.jpg?ik-s=6fe59773101d5548893ceb900459ab75a0f737ff"
+	if err := other.VerifySignedURL(signedUrl); err == nil {
+		t.Error("VerifySignedURL accepted a URL signed with another private key")
+	}
+}
+
+func TestTransformationString(t *testing.T) {
+	width, height, dpr := Float64(300), Float64(200.5), Float64(2)
+	aspectRatio, crop, cropMode, focus := String("4-3"), String("at_max"), String("pad_resize"), String("center")
+	quality, blur, x, y := Int32(80), Int32(10), Int32(5), Int32(-5)
+	format, radius, rotation, background := String("webp"), String("max"), String("90"), String("FFFFFF")
+	border, named, defaultImage, trim := String("5_FF0000"), String("thumb"), String("folder@@default.jpg"), String("true")
+	progressive, lossless, grayscale, original := Bool(true), Bool(false), Bool(true), Bool(true)
+	raw := String("l-text,i-Hello,l-end")
+	tests := []struct {
+		transformation Transformation
+		want           string
+	}{
+		{Transformation{}, ""},
+		{Transformation{Width: &width, Height: &height}, "w-300,h-200.5"},
+		{
+			Transformation{AspectRatio: &aspectRatio, Crop: &crop, CropMode: &cropMode, Focus: &focus},
+			"ar-4-3,c-at_max,cm-pad_resize,fo-center",
+		},
+		{Transformation{X: &x, Y: &y, Quality: &quality, Format: &format}, "x-5,y--5,q-80,f-webp"},
+		{
+			Transformation{Blur: &blur, Radius: &radius, Rotation: &rotation, Background: &background, Border: &border},
+			"bl-10,r-max,rt-90,bg-FFFFFF,b-5_FF0000",
+		},
+		{
+			Transformation{DPR: &dpr, NamedTransformation: &named, DefaultImage: &defaultImage, Trim: &trim},
+			"dpr-2,n-thumb,di-folder@@default.jpg,t-true",
+		},
+		{
+			Transformation{Progressive: &progressive, Lossless: &lossless, Grayscale: &grayscale, Original: &original},
+			"pr-true,lo-false,e-grayscale,orig-true",
+		},
+		{Transformation{Width: &width, Raw: &raw}, "w-300,l-text,i-Hello,l-end"},
+	}
+	for _, test := range tests {
+		str, err := test.transformation.ToString()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if str != test.want {
+			t.Errorf("ToString() = %q, want %q", str, test.want)
+		}
+	}
+	chain, err := buildTransformationString([]Transformation{
+		{Width: &width, Height: &height},
+		{},
+		{Radius: &radius},
+	})
+	if err != nil || chain != "w-300,h-200.5:r-max" {
+		t.Errorf("buildTransformationString = %q, %v, want %q", chain, err, "w-300,h-200.5:r-max")
+	}
+}
+
+func TestTransformationStringValidation(t *testing.T) {
+	crop, cropMode, format := String("stretch"), String("fill"), String("bmp")
+	quality, blur := Int32(101), Int32(0)
+	invalid := []Transformation{
+		{Crop: &crop},
+		{CropMode: &cropMode},
+		{Format: &format},
+		{Quality: &quality},
+		{Blur: &blur},
+	}
+	for _, transformation := range invalid {
+		if _, err := transformation.ToString(); err == nil {
+			t.Errorf("ToString(%+v) returned no error", transformation)
+		}
+	}
+}
+
+func TestURL(t *testing.T) {
+	imgKit := New("public_key_test", TEST_PRIVATE_KEY, TEST_URL_ENDPOINT)
+	width, radius := Float64(300), String("max")
+	path, src := String("/folder/default-image.jpg?v=1"), String("https://example.com/image.jpg?a=b")
+	query := String(TRANSFORMATION_POSITION_QUERY)
+	transformations := []Transformation{{Width: &width}, {Radius: &radius}}
+	tests := []struct {
+		options UrlOptions
+		want    string
+	}{
+		{
+			UrlOptions{Path: &path, Transformations: &transformations},
+			TEST_URL_ENDPOINT + "/tr:w-300:r-max/folder/default-image.jpg?v=1",
+		},
+		{
+			UrlOptions{Path: &path, Transformations: &transformations, TransformationPosition: &query},
+			TEST_URL_ENDPOINT + "/folder/default-image.jpg?tr=w-300%3Ar-max&v=1",
+		},
+		{
+			UrlOptions{Src: &src, Transformations: &transformations, QueryParameters: map[string]string{"c": "d"}},
+			"https://example.com/image.jpg?a=b&c=d&tr=w-300%3Ar-max",
+		},
+	}
+	for _, test := range tests {
+		options := test.options
+		urlStr, err := imgKit.URL(&options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if urlStr != test.want {
+			t.Errorf("URL = %s, want %s", urlStr, test.want)
+		}
+	}
+	if _, err := imgKit.URL(&UrlOptions{}); err == nil {
+		t.Error("URL without a path or src returned no error")
+	}
+}
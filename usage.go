@@ -0,0 +1,67 @@
+package imagekit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	USAGE_DATE_FORMAT    = "2006-01-02"
+	MAX_USAGE_RANGE_DAYS = 90
+)
+
+// Represents the usage of an account over a date range.
+type Usage struct {
+	BandwidthBytes            int64 `json:"bandwidthBytes" binding:"-"`
+	MediaLibraryStorageBytes  int64 `json:"mediaLibraryStorageBytes" binding:"-"`
+	VideoProcessingUnitsCount int64 `json:"videoProcessingUnitsCount" binding:"-"`
+	ExtensionUnitsCount       int64 `json:"extensionUnitsCount" binding:"-"`
+	OriginalCacheStorageBytes int64 `json:"originalCacheStorageBytes" binding:"-"`
+}
+
+// Get the usage of the account from the start date up to, but not
+// including, the end date. The range must be shorter than
+// MAX_USAGE_RANGE_DAYS.
+func (imgKit *ImageKit) GetUsage(
+	ctx context.Context,
+	startDate,
+	endDate time.Time) (usage *Usage, err error) {
+	startDay := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, time.UTC)
+	endDay := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, time.UTC)
+	if !endDay.After(startDay) {
+		return nil, errors.New("endDate must be after startDate")
+	}
+	if endDay.Sub(startDay) >= MAX_USAGE_RANGE_DAYS*24*time.Hour {
+		return nil, fmt.Errorf("date range must be shorter than %d days", MAX_USAGE_RANGE_DAYS)
+	}
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf(
+			"%s/accounts/usage?startDate=%s&endDate=%s",
+			imgKit.getBaseUrl(),
+			startDay.Format(USAGE_DATE_FORMAT),
+			endDay.Format(USAGE_DATE_FORMAT),
+		),
+		bytes.NewBufferString(""),
+	)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resBodyStr, err := imgKit.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	usage = &Usage{}
+	err = json.Unmarshal([]byte(resBodyStr), usage)
+	if err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
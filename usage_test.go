@@ -0,0 +1,37 @@
+package imagekit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetUsageRangeBoundary(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"bandwidthBytes": 21}`))
+	}))
+	defer server.Close()
+	imgKit := New("public", "private", "https://ik.imagekit.io/demo", WithBaseUrl(server.URL))
+	startDate := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	usage, err := imgKit.GetUsage(context.Background(), startDate, startDate.AddDate(0, 0, MAX_USAGE_RANGE_DAYS-1))
+	if err != nil {
+		t.Fatalf("range of %d days: unexpected error: %v", MAX_USAGE_RANGE_DAYS-1, err)
+	}
+	if usage.BandwidthBytes != 21 {
+		t.Errorf("bandwidthBytes = %d, want 21", usage.BandwidthBytes)
+	}
+
+	_, err = imgKit.GetUsage(context.Background(), startDate, startDate.AddDate(0, 0, MAX_USAGE_RANGE_DAYS))
+	if err == nil {
+		t.Fatalf("range of %d days: expected an error", MAX_USAGE_RANGE_DAYS)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+}
@@ -0,0 +1,399 @@
+package imagekit
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	WEBHOOK_SIGNATURE_HEADER  = "X-Ik-Signature"
+	DEFAULT_WEBHOOK_TOLERANCE = 5 * time.Minute
+	MAX_WEBHOOK_BODY_SIZE     = 1 << 20
+)
+
+const (
+	WEBHOOK_EVENT_VIDEO_TRANSFORMATION_ACCEPTED = "video.transformation.accepted"
+	WEBHOOK_EVENT_VIDEO_TRANSFORMATION_READY    = "video.transformation.ready"
+	WEBHOOK_EVENT_VIDEO_TRANSFORMATION_ERROR    = "video.transformation.error"
+	WEBHOOK_EVENT_UPLOAD_PRE_TRANSFORM_SUCCESS  = "upload.pre-transform.success"
+	WEBHOOK_EVENT_UPLOAD_PRE_TRANSFORM_ERROR    = "upload.pre-transform.error"
+	WEBHOOK_EVENT_UPLOAD_POST_TRANSFORM_SUCCESS = "upload.post-transform.success"
+	WEBHOOK_EVENT_UPLOAD_POST_TRANSFORM_ERROR   = "upload.post-transform.error"
+)
+
+// Represents a verified event sent to a webhook.
+type WebhookEvent struct {
+	Type      string          `json:"type" binding:"-"`
+	Id        string          `json:"id" binding:"-"`
+	CreatedAt *time.Time      `json:"created_at" binding:"-"`
+	Request   json.RawMessage `json:"request" binding:"-"`
+	Data      json.RawMessage `json:"data" binding:"-"`
+	Timestamp time.Time       `json:"-"`
+	Body      []byte          `json:"-"`
+}
+
+// Represents the fields common to all webhook events.
+type WebhookEventHeader struct {
+	Type      string     `json:"type" binding:"-"`
+	Id        string     `json:"id" binding:"-"`
+	CreatedAt *time.Time `json:"created_at" binding:"-"`
+}
+
+// Represents the reason a transformation failed.
+type WebhookError struct {
+	Reason string `json:"reason" binding:"-"`
+}
+
+// Represents the request that triggered a video transformation.
+type VideoTransformationRequest struct {
+	XRequestId string `json:"x_request_id" binding:"-"`
+	Url        string `json:"url" binding:"-"`
+	UserAgent  string `json:"user_agent" binding:"-"`
+}
+
+// Represents the options of a video transformation.
+type VideoTransformationOptions struct {
+	AudioCodec     string   `json:"audio_codec" binding:"-"`
+	AutoRotate     bool     `json:"auto_rotate" binding:"-"`
+	Format         string   `json:"format" binding:"-"`
+	Quality        int      `json:"quality" binding:"-"`
+	StreamProtocol string   `json:"stream_protocol" binding:"-"`
+	Variants       []string `json:"variants" binding:"-"`
+	VideoCodec     string   `json:"video_codec" binding:"-"`
+}
+
+// Represents metadata about a transformed video.
+type VideoMetadata struct {
+	Bitrate  int64   `json:"bitrate" binding:"-"`
+	Duration float64 `json:"duration" binding:"-"`
+	Height   int     `json:"height" binding:"-"`
+	Width    int     `json:"width" binding:"-"`
+}
+
+// Represents the output of a video transformation.
+type VideoTransformationOutput struct {
+	Url           string         `json:"url" binding:"-"`
+	VideoMetadata *VideoMetadata `json:"video_metadata" binding:"-"`
+}
+
+// Represents a video transformation.
+type VideoTransformation struct {
+	Type    string                     `json:"type" binding:"-"`
+	Options VideoTransformationOptions `json:"options" binding:"-"`
+}
+
+// Represents the asset a video transformation is applied to.
+type VideoTransformationAsset struct {
+	Url string `json:"url" binding:"-"`
+}
+
+// Represents an event sent when a video transformation is accepted.
+type VideoTransformationAcceptedEvent struct {
+	WebhookEventHeader
+	Request VideoTransformationRequest `json:"request" binding:"-"`
+	Data    struct {
+		Asset          VideoTransformationAsset `json:"asset" binding:"-"`
+		Transformation VideoTransformation      `json:"transformation" binding:"-"`
+	} `json:"data" binding:"-"`
+}
+
+// Represents an event sent when a transformed video is ready.
+type VideoTransformationReadyEvent struct {
+	WebhookEventHeader
+	Request VideoTransformationRequest `json:"request" binding:"-"`
+	Data    struct {
+		Asset          VideoTransformationAsset `json:"asset" binding:"-"`
+		Transformation struct {
+			VideoTransformation
+			Output VideoTransformationOutput `json:"output" binding:"-"`
+		} `json:"transformation" binding:"-"`
+	} `json:"data" binding:"-"`
+	Timings struct {
+		DownloadDuration int64 `json:"download_duration" binding:"-"`
+		EncodingDuration int64 `json:"encoding_duration" binding:"-"`
+	} `json:"timings" binding:"-"`
+}
+
+// Represents an event sent when a video transformation fails.
+type VideoTransformationErrorEvent struct {
+	WebhookEventHeader
+	Request VideoTransformationRequest `json:"request" binding:"-"`
+	Data    struct {
+		Asset          VideoTransformationAsset `json:"asset" binding:"-"`
+		Transformation struct {
+			VideoTransformation
+			Error WebhookError `json:"error" binding:"-"`
+		} `json:"transformation" binding:"-"`
+	} `json:"data" binding:"-"`
+}
+
+// Represents the request of an upload with a pre-transformation.
+type UploadPreTransformRequest struct {
+	XRequestId     string `json:"x_request_id" binding:"-"`
+	Transformation string `json:"transformation" binding:"-"`
+}
+
+// Represents an event sent when a pre-transformation of an upload succeeds.
+type UploadPreTransformSuccessEvent struct {
+	WebhookEventHeader
+	Request UploadPreTransformRequest `json:"request" binding:"-"`
+	Data    FileDetails               `json:"data" binding:"-"`
+}
+
+// Represents an event sent when a pre-transformation of an upload fails.
+type UploadPreTransformErrorEvent struct {
+	WebhookEventHeader
+	Request UploadPreTransformRequest `json:"request" binding:"-"`
+	Data    struct {
+		Name           string `json:"name" binding:"-"`
+		Path           string `json:"path" binding:"-"`
+		Transformation struct {
+			Error WebhookError `json:"error" binding:"-"`
+		} `json:"transformation" binding:"-"`
+	} `json:"data" binding:"-"`
+}
+
+// Represents the request of an upload with a post-transformation.
+type UploadPostTransformRequest struct {
+	XRequestId     string `json:"x_request_id" binding:"-"`
+	Transformation struct {
+		Type     string `json:"type" binding:"-"`
+		Value    string `json:"value" binding:"-"`
+		Protocol string `json:"protocol" binding:"-"`
+	} `json:"transformation" binding:"-"`
+}
+
+// Represents an event sent when a post-transformation of an upload succeeds.
+type UploadPostTransformSuccessEvent struct {
+	WebhookEventHeader
+	Request UploadPostTransformRequest `json:"request" binding:"-"`
+	Data    struct {
+		FileId string `json:"fileId" binding:"-"`
+		Url    string `json:"url" binding:"-"`
+		Name   string `json:"name" binding:"-"`
+	} `json:"data" binding:"-"`
+}
+
+// Represents an event sent when a post-transformation of an upload fails.
+type UploadPostTransformErrorEvent struct {
+	WebhookEventHeader
+	Request UploadPostTransformRequest `json:"request" binding:"-"`
+	Data    struct {
+		FileId         string `json:"fileId" binding:"-"`
+		Url            string `json:"url" binding:"-"`
+		Name           string `json:"name" binding:"-"`
+		Path           string `json:"path" binding:"-"`
+		Transformation struct {
+			Error WebhookError `json:"error" binding:"-"`
+		} `json:"transformation" binding:"-"`
+	} `json:"data" binding:"-"`
+}
+
+// Decodes the event into the typed struct of its type, such as
+// *VideoTransformationReadyEvent.
+func (event *WebhookEvent) Decode() (payload interface{}, err error) {
+	switch event.Type {
+	case WEBHOOK_EVENT_VIDEO_TRANSFORMATION_ACCEPTED:
+		payload = &VideoTransformationAcceptedEvent{}
+	case WEBHOOK_EVENT_VIDEO_TRANSFORMATION_READY:
+		payload = &VideoTransformationReadyEvent{}
+	case WEBHOOK_EVENT_VIDEO_TRANSFORMATION_ERROR:
+		payload = &VideoTransformationErrorEvent{}
+	case WEBHOOK_EVENT_UPLOAD_PRE_TRANSFORM_SUCCESS:
+		payload = &UploadPreTransformSuccessEvent{}
+	case WEBHOOK_EVENT_UPLOAD_PRE_TRANSFORM_ERROR:
+		payload = &UploadPreTransformErrorEvent{}
+	case WEBHOOK_EVENT_UPLOAD_POST_TRANSFORM_SUCCESS:
+		payload = &UploadPostTransformSuccessEvent{}
+	case WEBHOOK_EVENT_UPLOAD_POST_TRANSFORM_ERROR:
+		payload = &UploadPostTransformErrorEvent{}
+	default:
+		return nil, fmt.Errorf("unknown webhook event type %q", event.Type)
+	}
+	if err = json.Unmarshal(event.Body, payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// Parses the timestamp and signature from a signature header of the form
+// "t=<timestamp>,v1=<signature>".
+func parseWebhookSignature(
+	signatureHeader string) (timestamp int64, signature string, err error) {
+	timestampStr := ""
+	for _, item := range strings.Split(signatureHeader, ",") {
+		i := strings.Index(item, "=")
+		if i < 0 {
+			continue
+		}
+		switch strings.TrimSpace(item[:i]) {
+		case "t":
+			timestampStr = strings.TrimSpace(item[i+1:])
+		case "v1":
+			signature = strings.TrimSpace(item[i+1:])
+		}
+	}
+	if len(timestampStr) == 0 || len(signature) == 0 {
+		return 0, "", errors.New("invalid webhook signature header")
+	}
+	timestamp, err = strconv.ParseInt(timestampStr, 10, 64)
+	if err != nil {
+		return 0, "", errors.New("invalid webhook timestamp")
+	}
+	return timestamp, signature, nil
+}
+
+// Verifies the signature of a webhook request body and decodes its event,
+// rejecting events signed more than DEFAULT_WEBHOOK_TOLERANCE ago.
+func VerifyWebhookEvent(
+	body []byte,
+	signatureHeader,
+	secret string) (event *WebhookEvent, err error) {
+	return VerifyWebhookEventWithTolerance(
+		body,
+		signatureHeader,
+		secret,
+		DEFAULT_WEBHOOK_TOLERANCE,
+	)
+}
+
+// Verifies the signature of a webhook request body and decodes its event,
+// rejecting events whose timestamp differs from the current time by more
+// than the tolerance. The timestamp is not checked if tolerance is 0.
+func VerifyWebhookEventWithTolerance(
+	body []byte,
+	signatureHeader,
+	secret string,
+	tolerance time.Duration) (event *WebhookEvent, err error) {
+	signedAt, err := verifyWebhookSignature(body, signatureHeader, secret, tolerance)
+	if err != nil {
+		return nil, err
+	}
+	return decodeWebhookEvent(body, signedAt)
+}
+
+// Verifies the signature and timestamp of a webhook request body, returning
+// the time it was signed at.
+func verifyWebhookSignature(
+	body []byte,
+	signatureHeader,
+	secret string,
+	tolerance time.Duration) (signedAt time.Time, err error) {
+	if len(secret) == 0 {
+		return time.Time{}, errors.New("secret must not be empty")
+	}
+	timestamp, signature, err := parseWebhookSignature(signatureHeader)
+	if err != nil {
+		return time.Time{}, err
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%d.", timestamp)))
+	mac.Write(body)
+	expectedSignature := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(signature), []byte(expectedSignature)) {
+		return time.Time{}, errors.New("invalid webhook signature")
+	}
+	signedAt = time.Unix(0, timestamp*int64(time.Millisecond))
+	if tolerance > 0 {
+		age := time.Since(signedAt)
+		if age > tolerance || age < -tolerance {
+			return time.Time{}, errors.New("webhook timestamp is outside the tolerance")
+		}
+	}
+	return signedAt, nil
+}
+
+// Decodes the event of a verified webhook request body.
+func decodeWebhookEvent(body []byte, signedAt time.Time) (event *WebhookEvent, err error) {
+	event = &WebhookEvent{}
+	if err = json.Unmarshal(body, event); err != nil {
+		return nil, err
+	}
+	event.Timestamp = signedAt
+	event.Body = body
+	return event, nil
+}
+
+// Represents an http.Handler that verifies webhook requests and dispatches
+// their events to the callbacks registered for their type.
+type WebhookHandler struct {
+	Secret string
+	// The maximum difference between the timestamp of an event and the
+	// current time, which defaults to DEFAULT_WEBHOOK_TOLERANCE when nil.
+	// The timestamp is not checked if it is 0.
+	Tolerance *time.Duration
+	mutex     sync.RWMutex
+	callbacks map[string][]func(event *WebhookEvent) error
+}
+
+// Registers a callback for events of the given type. Callbacks registered
+// for "*" receive events of every type.
+func (handler *WebhookHandler) On(
+	eventType string,
+	callback func(event *WebhookEvent) error) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	if handler.callbacks == nil {
+		handler.callbacks = make(map[string][]func(event *WebhookEvent) error)
+	}
+	handler.callbacks[eventType] = append(handler.callbacks[eventType], callback)
+}
+
+// Verifies the webhook request and runs the callbacks of its event. The
+// errors returned by the callbacks are not written to the response.
+func (handler *WebhookHandler) ServeHTTP(
+	w http.ResponseWriter,
+	r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		writeJSONError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MAX_WEBHOOK_BODY_SIZE))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "failed to read body")
+		return
+	}
+	tolerance := DEFAULT_WEBHOOK_TOLERANCE
+	if handler.Tolerance != nil {
+		tolerance = *handler.Tolerance
+	}
+	signedAt, err := verifyWebhookSignature(
+		body,
+		r.Header.Get(WEBHOOK_SIGNATURE_HEADER),
+		handler.Secret,
+		tolerance,
+	)
+	if err != nil {
+		writeJSONError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	event, err := decodeWebhookEvent(body, signedAt)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid webhook event")
+		return
+	}
+	handler.mutex.RLock()
+	callbacks := append(
+		append([]func(event *WebhookEvent) error{}, handler.callbacks[event.Type]...),
+		handler.callbacks["*"]...,
+	)
+	handler.mutex.RUnlock()
+	for _, callback := range callbacks {
+		if err = callback(event); err != nil {
+			writeJSONError(w, http.StatusInternalServerError, "failed to handle webhook event")
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,149 @@
+package imagekit
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	TEST_WEBHOOK_SECRET = "whsec_test"
+	TEST_WEBHOOK_BODY   = `{"type":"video.transformation.accepted","id":"58e6d24d-6098-4319-be8d-40c3cb0a402d","created_at":"2024-07-25T10:16:52.497Z","request":{"x_request_id":"fa98fa2e-d6cd-45b4-acf5-bc1d2bbb8ba9","url":"https://ik.imagekit.io/demo/sample-video.mp4?tr=f-webm","user_agent":"curl/8.6.0"},"data":{"asset":{"url":"https://ik.imagekit.io/demo/sample-video.mp4"},"transformation":{"type":"video-transformation","options":{"format":"webm"}}}}`
+)
+
+// Signs a webhook request body as of the given time.
+func signWebhookBody(body string, signedAt time.Time) string {
+	timestamp := signedAt.UnixNano() / int64(time.Millisecond)
+	mac := hmac.New(sha256.New, []byte(TEST_WEBHOOK_SECRET))
+	mac.Write([]byte(fmt.Sprintf("%d.%s", timestamp, body)))
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+func TestVerifyWebhookEventSignature(t *testing.T) {
+	signatureHeader := "t=1721902612497,v1=71ddeece6dc5b75dc58b37cb2998a3a85940c9b9cea49b2af3b5f4328564c1dc"
+	event, err := VerifyWebhookEventWithTolerance([]byte(TEST_WEBHOOK_BODY), signatureHeader, TEST_WEBHOOK_SECRET, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.Type != WEBHOOK_EVENT_VIDEO_TRANSFORMATION_ACCEPTED || event.Id != "58e6d24d-6098-4319-be8d-40c3cb0a402d" {
+		t.Errorf("event = %s %s", event.Type, event.Id)
+	}
+	if event.Timestamp.UnixNano()/int64(time.Millisecond) != 1721902612497 {
+		t.Errorf("Timestamp = %v", event.Timestamp)
+	}
+	payload, err := event.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	accepted, ok := payload.(*VideoTransformationAcceptedEvent)
+	if !ok || accepted.Data.Transformation.Options.Format != "webm" {
+		t.Errorf("payload = %+v", payload)
+	}
+	invalid := []struct {
+		body, secret string
+	}{
+		{strings.Replace(TEST_WEBHOOK_BODY, "webm", "mp4", 1), TEST_WEBHOOK_SECRET},
+		{TEST_WEBHOOK_BODY, "whsec_other"},
+	}
+	for _, test := range invalid {
+		if _, err := VerifyWebhookEventWithTolerance([]byte(test.body), signatureHeader, test.secret, 0); err == nil {
+			t.Errorf("accepted body %s with secret %s", test.body, test.secret)
+		}
+	}
+}
+
+func TestVerifyWebhookEventTimestamp(t *testing.T) {
+	body := []byte(TEST_WEBHOOK_BODY)
+	if _, err := VerifyWebhookEvent(body, signWebhookBody(TEST_WEBHOOK_BODY, time.Now()), TEST_WEBHOOK_SECRET); err != nil {
+		t.Errorf("rejected a fresh event: %v", err)
+	}
+	for _, signedAt := range []time.Time{time.Now().Add(-10 * time.Minute), time.Now().Add(10 * time.Minute)} {
+		signatureHeader := signWebhookBody(TEST_WEBHOOK_BODY, signedAt)
+		if _, err := VerifyWebhookEvent(body, signatureHeader, TEST_WEBHOOK_SECRET); err == nil {
+			t.Errorf("accepted an event signed at %v", signedAt)
+		}
+		if _, err := VerifyWebhookEventWithTolerance(body, signatureHeader, TEST_WEBHOOK_SECRET, time.Hour); err != nil {
+			t.Errorf("rejected an event signed at %v within the tolerance: %v", signedAt, err)
+		}
+		if _, err := VerifyWebhookEventWithTolerance(body, signatureHeader, TEST_WEBHOOK_SECRET, 0); err != nil {
+			t.Errorf("checked the timestamp with a tolerance of 0: %v", err)
+		}
+	}
+}
+
+func TestVerifyWebhookEventMalformedHeader(t *testing.T) {
+	valid := signWebhookBody(TEST_WEBHOOK_BODY, time.Now())
+	signature := valid[strings.Index(valid, "v1="):]
+	headers := []string{
+		"",
+		"garbage",
+		signature,
+		"t=1721902612497",
+		"t=abc," + signature,
+		"t=," + signature,
+	}
+	for _, header := range headers {
+		if _, err := VerifyWebhookEvent([]byte(TEST_WEBHOOK_BODY), header, TEST_WEBHOOK_SECRET); err == nil {
+			t.Errorf("accepted signature header %q", header)
+		}
+	}
+	if _, err := VerifyWebhookEvent([]byte(TEST_WEBHOOK_BODY), valid, ""); err == nil {
+		t.Error("accepted an empty secret")
+	}
+}
+
+// Sends a webhook request to a handler, returning the response.
+func serveWebhook(handler *WebhookHandler, body, signatureHeader string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/imagekit", strings.NewReader(body))
+	req.Header.Set(WEBHOOK_SIGNATURE_HEADER, signatureHeader)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestWebhookHandler(t *testing.T) {
+	handler := &WebhookHandler{Secret: TEST_WEBHOOK_SECRET}
+	received := []string{}
+	handler.On(WEBHOOK_EVENT_VIDEO_TRANSFORMATION_ACCEPTED, func(event *WebhookEvent) error {
+		received = append(received, event.Id)
+		return nil
+	})
+	res := serveWebhook(handler, TEST_WEBHOOK_BODY, signWebhookBody(TEST_WEBHOOK_BODY, time.Now()))
+	if res.Code != http.StatusNoContent || len(received) != 1 {
+		t.Errorf("status = %d, received = %v", res.Code, received)
+	}
+	stale := signWebhookBody(TEST_WEBHOOK_BODY, time.Now().Add(-time.Hour))
+	if res = serveWebhook(handler, TEST_WEBHOOK_BODY, stale); res.Code != http.StatusUnauthorized {
+		t.Errorf("stale event status = %d, want 401", res.Code)
+	}
+	tolerance := time.Duration(0)
+	handler.Tolerance = &tolerance
+	if res = serveWebhook(handler, TEST_WEBHOOK_BODY, stale); res.Code != http.StatusNoContent {
+		t.Errorf("stale event status with the check disabled = %d, want 204", res.Code)
+	}
+	malformed := `{"type":`
+	if res = serveWebhook(handler, malformed, signWebhookBody(malformed, time.Now())); res.Code != http.StatusBadRequest {
+		t.Errorf("malformed event status = %d, want 400", res.Code)
+	}
+}
+
+func TestWebhookHandlerHidesCallbackErrors(t *testing.T) {
+	handler := &WebhookHandler{Secret: TEST_WEBHOOK_SECRET}
+	handler.On("*", func(event *WebhookEvent) error {
+		return errors.New("database password is hunter2")
+	})
+	res := serveWebhook(handler, TEST_WEBHOOK_BODY, signWebhookBody(TEST_WEBHOOK_BODY, time.Now()))
+	if res.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", res.Code)
+	}
+	if strings.Contains(res.Body.String(), "hunter2") {
+		t.Errorf("response body %s contains the callback error", res.Body.String())
+	}
+}